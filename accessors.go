@@ -0,0 +1,157 @@
+package dataframe
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Col returns a copy of a column as a []T. T must be the Go type the column stores (int64, float64,
+// string, bool or time.Time), in which case missing values read as the zero value; a pointer to that
+// type, in which case missing values read as nil; or interface{}, in which case they read as nil.
+func Col[T any](df *DataFrame, name string) ([]T, error) {
+	s, err := df.Column(name)
+	if err != nil {
+		return nil, err
+	}
+
+	read, err := accessor[T](s)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]T, s.Len())
+	for i := range values {
+		values[i], _ = read(i)
+	}
+	return values, nil
+}
+
+// At returns the value of a column at the given row as T, following the type rules of Col. Reading a
+// missing value into a non-pointer T returns ErrNullValue.
+func At[T any](df *DataFrame, row int, column string) (T, error) {
+	var zero T
+	s, err := df.Column(column)
+	if err != nil {
+		return zero, err
+	}
+	if row < 0 || row >= s.Len() {
+		return zero, fmt.Errorf("row index %d out of range", row)
+	}
+
+	read, err := accessor[T](s)
+	if err != nil {
+		return zero, err
+	}
+
+	value, valid := read(row)
+	if !valid {
+		return zero, fmt.Errorf("column '%s' row %d: %w", column, row, ErrNullValue)
+	}
+	return value, nil
+}
+
+// Set returns a new DataFrame with a value stored in a column at the given row, following the type
+// rules of Col; a nil pointer or nil interface stores a missing value. A column with no type yet takes
+// the type of the value. Only the updated column is copied.
+func Set[T any](df *DataFrame, row int, column string, value T) (*DataFrame, error) {
+	i, ok := df.index[column]
+	if !ok {
+		return nil, fmt.Errorf("column '%s' does not exist", column)
+	}
+	s := df.columns[i]
+	if row < 0 || row >= s.Len() {
+		return nil, fmt.Errorf("row index %d out of range", row)
+	}
+
+	boxed := boxValue(value)
+	dtype, data := s.dtype, s.data
+	if dtype == Null {
+		if boxed == nil {
+			return df, nil
+		}
+		var ok bool
+		if dtype, ok = dtypeOf(boxed); !ok {
+			return nil, fmt.Errorf("unsupported value type %T", boxed)
+		}
+		data = newColumn(dtype, s.Len())
+		for j := 0; j < s.Len(); j++ {
+			data.appendNull()
+		}
+	} else {
+		if _, err := accessor[T](s); err != nil {
+			return nil, err
+		}
+		data = data.Clone()
+	}
+
+	if !data.set(row, boxed) {
+		return nil, &TypeMismatchError{Column: column, Row: row, Expected: dtype, Value: boxed}
+	}
+	return df.withColumn(i, &Series{name: s.name, dtype: dtype, data: data}), nil
+}
+
+// boxValue returns value as an interface{}, dereferencing pointers and mapping nil pointers to nil
+func boxValue(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		return v.Elem().Interface()
+	}
+	return value
+}
+
+// accessor returns a function reading rows of the Series as T, together with whether the row holds
+// a value; it fails when T is not a type the Series can be read as
+func accessor[T any](s *Series) (func(i int) (T, bool), error) {
+	if v, ok := s.data.(*vector[T]); ok {
+		return func(i int) (T, bool) {
+			return v.data[i], v.valid.get(i)
+		}, nil
+	}
+
+	var zero T
+	switch any(zero).(type) {
+	case *int64:
+		return pointerAccessor[int64, T](s)
+	case *float64:
+		return pointerAccessor[float64, T](s)
+	case *string:
+		return pointerAccessor[string, T](s)
+	case *bool:
+		return pointerAccessor[bool, T](s)
+	case *time.Time:
+		return pointerAccessor[time.Time, T](s)
+	}
+
+	if reflect.TypeFor[T]() == reflect.TypeFor[interface{}]() {
+		return func(i int) (T, bool) {
+			value, _ := s.Value(i).(T)
+			return value, true
+		}, nil
+	}
+	return nil, columnTypeError[T](s)
+}
+
+// pointerAccessor returns a function reading rows of a Series storing E as T, which must be *E
+func pointerAccessor[E, T any](s *Series) (func(i int) (T, bool), error) {
+	v, ok := s.data.(*vector[E])
+	if !ok {
+		return nil, columnTypeError[T](s)
+	}
+	return func(i int) (T, bool) {
+		var p *E
+		if v.valid.get(i) {
+			value := v.data[i]
+			p = &value
+		}
+		return any(p).(T), true
+	}, nil
+}
+
+// columnTypeError reports that a Series cannot be read as T
+func columnTypeError[T any](s *Series) error {
+	return fmt.Errorf("column '%s' holds %s values, not %s", s.name, s.dtype, reflect.TypeFor[T]())
+}
@@ -0,0 +1,74 @@
+package dataframe
+
+import (
+	"math/bits"
+	"sync/atomic"
+)
+
+// bitmap stores one bit per row; a set bit marks a valid (non-null) value. Vectors sharing a bitmap
+// may append to its last word while others read it, so words are always read atomically.
+type bitmap []uint64
+
+// newBitmap returns a bitmap for n rows with every bit set to valid
+func newBitmap(n int, valid bool) bitmap {
+	b := make(bitmap, (n+63)/64)
+	if valid {
+		for i := range b {
+			b[i] = ^uint64(0)
+		}
+	}
+	return b
+}
+
+// get reports whether row i is valid
+func (b bitmap) get(i int) bool {
+	return atomic.LoadUint64(&b[i>>6])&(1<<(uint(i)&63)) != 0
+}
+
+// set marks row i as valid or null in a bitmap not yet visible to other vectors
+func (b bitmap) set(i int, valid bool) {
+	if valid {
+		b[i>>6] |= 1 << (uint(i) & 63)
+	} else {
+		b[i>>6] &^= 1 << (uint(i) & 63)
+	}
+}
+
+// setShared marks row i as valid or null in a bitmap whose words other vectors may be reading
+func (b bitmap) setShared(i int, valid bool) {
+	if valid {
+		atomic.OrUint64(&b[i>>6], 1<<(uint(i)&63))
+	} else {
+		atomic.AndUint64(&b[i>>6], ^uint64(1<<(uint(i)&63)))
+	}
+}
+
+// clone returns a copy of the bitmap with room for at least capacity rows
+func (b bitmap) clone(capacity int) bitmap {
+	out := make(bitmap, len(b), max(len(b), (capacity+63)/64))
+	for i := range b {
+		out[i] = atomic.LoadUint64(&b[i])
+	}
+	return out
+}
+
+// grow returns the bitmap extended so that it can hold n rows
+func (b bitmap) grow(n int) bitmap {
+	for len(b)*64 < n {
+		b = append(b, 0)
+	}
+	return b
+}
+
+// count returns the number of valid rows among the first n
+func (b bitmap) count(n int) int {
+	total := 0
+	full := n >> 6
+	for i := 0; i < full; i++ {
+		total += bits.OnesCount64(atomic.LoadUint64(&b[i]))
+	}
+	if rem := uint(n) & 63; rem != 0 {
+		total += bits.OnesCount64(atomic.LoadUint64(&b[full]) & (1<<rem - 1))
+	}
+	return total
+}
@@ -0,0 +1,136 @@
+package dataframe
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+)
+
+// frameOf builds a DataFrame around the given Series, which must have distinct names
+func frameOf(columns []*Series) *DataFrame {
+	df := &DataFrame{index: make(map[string]int, len(columns))}
+	for _, s := range columns {
+		df.appendSeries(s)
+	}
+	return df
+}
+
+// Select returns a new DataFrame holding the given columns in the given order; the columns
+// share their storage with this DataFrame
+func (df *DataFrame) Select(columns ...string) (*DataFrame, error) {
+	if len(columns) == 0 {
+		return nil, errors.New("column names are required")
+	}
+
+	selected := make([]*Series, len(columns))
+	seen := make(map[string]bool, len(columns))
+	for i, columnName := range columns {
+		if seen[columnName] {
+			return nil, fmt.Errorf("column '%s' appears more than once", columnName)
+		}
+		seen[columnName] = true
+
+		s, err := df.Column(columnName)
+		if err != nil {
+			return nil, err
+		}
+		selected[i] = s
+	}
+
+	return frameOf(selected), nil
+}
+
+// Drop returns a new DataFrame without the given columns
+func (df *DataFrame) Drop(columns ...string) (*DataFrame, error) {
+	dropped := make(map[string]bool, len(columns))
+	for _, columnName := range columns {
+		if _, ok := df.index[columnName]; !ok {
+			return nil, fmt.Errorf("column '%s' does not exist", columnName)
+		}
+		dropped[columnName] = true
+	}
+	if len(dropped) == len(df.columns) {
+		return nil, errors.New("cannot drop every column")
+	}
+
+	return df.selectWhere(func(s *Series) bool {
+		return !dropped[s.name]
+	})
+}
+
+// Rename returns a new DataFrame with columns renamed according to a map from old to new name
+func (df *DataFrame) Rename(names map[string]string) (*DataFrame, error) {
+	for oldName := range names {
+		if _, ok := df.index[oldName]; !ok {
+			return nil, fmt.Errorf("column '%s' does not exist", oldName)
+		}
+	}
+
+	renamed := make([]*Series, len(df.columns))
+	seen := make(map[string]bool, len(df.columns))
+	for i, s := range df.columns {
+		renamed[i] = s
+		if newName, ok := names[s.name]; ok {
+			renamed[i] = s.rename(newName)
+		}
+		if seen[renamed[i].name] {
+			return nil, fmt.Errorf("column name '%s' already exists", renamed[i].name)
+		}
+		seen[renamed[i].name] = true
+	}
+
+	return frameOf(renamed), nil
+}
+
+// SelectByType returns a new DataFrame holding the columns of the given types
+func (df *DataFrame) SelectByType(dtypes ...DType) (*DataFrame, error) {
+	wanted := make(map[DType]bool, len(dtypes))
+	for _, dtype := range dtypes {
+		wanted[dtype] = true
+	}
+
+	return df.selectWhere(func(s *Series) bool {
+		return wanted[s.dtype]
+	})
+}
+
+// SelectRegex returns a new DataFrame holding the columns whose names match the regular expression
+func (df *DataFrame) SelectRegex(pattern string) (*DataFrame, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return df.selectWhere(func(s *Series) bool {
+		return re.MatchString(s.name)
+	})
+}
+
+// SelectGlob returns a new DataFrame holding the columns whose names match the glob pattern,
+// using the syntax of path.Match
+func (df *DataFrame) SelectGlob(pattern string) (*DataFrame, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+
+	return df.selectWhere(func(s *Series) bool {
+		matched, _ := path.Match(pattern, s.name)
+		return matched
+	})
+}
+
+// selectWhere returns a new DataFrame holding the columns accepted by keep, in their current order
+func (df *DataFrame) selectWhere(keep func(s *Series) bool) (*DataFrame, error) {
+	selected := make([]*Series, 0, len(df.columns))
+	for _, s := range df.columns {
+		if keep(s) {
+			selected = append(selected, s)
+		}
+	}
+
+	if len(selected) == 0 {
+		return nil, errors.New("no columns matched the selection")
+	}
+	return frameOf(selected), nil
+}
@@ -1,11 +1,523 @@
 package dataframe
 
-// Function to import data from different file formats
-func (df *DataFrame) ImportData() {
-	// Implement your data import logic here
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVOptions configures ReadCSV and CSVChunkReader. Delimiter and Quote default to ',' and '"';
+// Quote must be an ASCII character. Lines starting with Comment are skipped, and so are the first
+// SkipRows lines of the input. When Header is set the first record names the columns, otherwise
+// they are named column_0, column_1 and so on. Empty fields and fields equal to one of NullValues
+// are missing. Types fixes the type of the named columns; the others are inferred from their first
+// InferRows values, which defaults to DefaultInferRows, while a negative InferRows examines every
+// row. DecimalSeparator, defaulting to '.', is the character separating the fraction of floats.
+type CSVOptions struct {
+	Delimiter        rune
+	Header           bool
+	Quote            rune
+	Comment          rune
+	SkipRows         int
+	NullValues       []string
+	Types            map[string]DType
+	InferRows        int
+	DecimalSeparator rune
+}
+
+// CSVError reports a malformed CSV record, with the line of the input where reading failed and,
+// when known, the column of the field that could not be read
+type CSVError struct {
+	Line   int
+	Column string
+	Err    error
+}
+
+// Error returns a description of the malformed record
+func (e *CSVError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("line %d, column '%s': %v", e.Line, e.Column, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *CSVError) Unwrap() error {
+	return e.Err
+}
+
+// csvTimeLayouts are the layouts tried, in order, when reading Datetime fields
+var csvTimeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+// ReadCSV reads a DataFrame from CSV input, parsing each record as it is read. An Int64 column
+// whose later values turn out to hold fractions becomes a Float64 column, and a column whose
+// inferred values are all missing takes the type of its first value; any other value that does not
+// parse as its column's type is reported as a *CSVError.
+func ReadCSV(r io.Reader, opts CSVOptions) (*DataFrame, error) {
+	reader, err := NewCSVChunkReader(r, opts, 0)
+	if err != nil {
+		return nil, err
+	}
+	df, _, err := reader.read(-1)
+	return df, err
+}
+
+// CSVChunkReader reads CSV input as a sequence of DataFrames of at most a set number of rows, so
+// that inputs larger than memory can be processed piece by piece. Column types are settled on the
+// first InferRows values and shared by all chunks, except that an Int64 column may become a
+// Float64 column, or a column of missing values take a type, from the chunk where the need arises.
+type CSVChunkReader struct {
+	reader    *csv.Reader
+	chunkRows int
+	lineShift int
+	quote     rune
+	decimal   rune
+	nulls     map[string]bool
+	names     []string
+	types     []DType
+	fixed     []bool
+	pending   []csvRecord
+	err       error
 }
 
-// Function to export DataFrame to different file formats
-func (df *DataFrame) ExportData() {
-	// Implement your data export logic here
+// csvRecord holds the fields of a record read ahead to infer column types, with its line
+type csvRecord struct {
+	fields []string
+	line   int
+}
+
+// NewCSVChunkReader returns a CSVChunkReader returning chunks of at most chunkRows rows; a
+// chunkRows of zero or less returns all rows in one chunk. The header and the rows used to infer
+// column types are read immediately.
+func NewCSVChunkReader(r io.Reader, opts CSVOptions, chunkRows int) (*CSVChunkReader, error) {
+	cr := &CSVChunkReader{chunkRows: chunkRows, quote: opts.Quote, decimal: opts.DecimalSeparator, lineShift: max(opts.SkipRows, 0)}
+	if cr.quote == 0 {
+		cr.quote = '"'
+	}
+	if cr.decimal == 0 {
+		cr.decimal = '.'
+	}
+	delimiter := opts.Delimiter
+	if delimiter == 0 {
+		delimiter = ','
+	}
+	if cr.quote > 0x7f || cr.quote == delimiter || cr.quote == opts.Comment || cr.quote == '\r' || cr.quote == '\n' {
+		return nil, fmt.Errorf("invalid quote character %q", cr.quote)
+	}
+	if cr.decimal == delimiter {
+		return nil, fmt.Errorf("decimal separator %q is also the delimiter", cr.decimal)
+	}
+
+	buffered := bufio.NewReader(r)
+	for i := 0; i < cr.lineShift; i++ {
+		if _, err := buffered.ReadString('\n'); err != nil {
+			if err == io.EOF {
+				return nil, errors.New("CSV input is empty")
+			}
+			return nil, err
+		}
+	}
+	var source io.Reader = buffered
+	if cr.quote != '"' {
+		source = &quoteSwapper{r: buffered, quote: byte(cr.quote)}
+	}
+	cr.reader = csv.NewReader(source)
+	cr.reader.Comma = delimiter
+	cr.reader.Comment = opts.Comment
+	cr.reader.ReuseRecord = true
+
+	cr.nulls = map[string]bool{"": true}
+	for _, value := range opts.NullValues {
+		cr.nulls[value] = true
+	}
+
+	first, line, err := cr.next()
+	if err == io.EOF {
+		return nil, errors.New("CSV input is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if opts.Header {
+		cr.names = slices.Clone(first)
+		header := make(Schema, len(cr.names))
+		for c, name := range cr.names {
+			header[c] = Field{Name: name}
+		}
+		if err := header.Validate(); err != nil {
+			return nil, err
+		}
+	} else {
+		cr.names = make([]string, len(first))
+		for c := range cr.names {
+			cr.names[c] = fmt.Sprintf("column_%d", c)
+		}
+		cr.pending = append(cr.pending, csvRecord{slices.Clone(first), line})
+	}
+
+	cr.types = make([]DType, len(cr.names))
+	cr.fixed = make([]bool, len(cr.names))
+	for columnName, dtype := range opts.Types {
+		c := slices.Index(cr.names, columnName)
+		if c < 0 {
+			return nil, fmt.Errorf("column '%s' does not exist", columnName)
+		}
+		cr.types[c], cr.fixed[c] = dtype, true
+	}
+
+	inferRows := opts.InferRows
+	if inferRows == 0 {
+		inferRows = DefaultInferRows
+	}
+	for inferRows < 0 || len(cr.pending) < inferRows {
+		fields, line, err := cr.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		cr.pending = append(cr.pending, csvRecord{slices.Clone(fields), line})
+	}
+	for c := range cr.types {
+		if cr.fixed[c] {
+			continue
+		}
+		for _, record := range cr.pending {
+			if value := record.fields[c]; !cr.nulls[value] {
+				cr.types[c] = widenCSVType(cr.types[c], cr.inferValue(value))
+			}
+		}
+	}
+	return cr, nil
+}
+
+// Schema returns the names and types of the columns as currently settled
+func (cr *CSVChunkReader) Schema() Schema {
+	schema := make(Schema, len(cr.names))
+	for c, name := range cr.names {
+		schema[c] = Field{Name: name, Type: cr.types[c]}
+	}
+	return schema
+}
+
+// Next returns the next chunk of rows, or io.EOF once every row has been returned
+func (cr *CSVChunkReader) Next() (*DataFrame, error) {
+	df, n, err := cr.read(cr.chunkRows)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, io.EOF
+	}
+	return df, nil
+}
+
+// read returns a DataFrame of at most limit rows, or of all remaining rows when limit is zero or
+// less, together with the number of rows read
+func (cr *CSVChunkReader) read(limit int) (*DataFrame, int, error) {
+	if cr.err != nil {
+		return nil, 0, cr.err
+	}
+	capacity := len(cr.pending)
+	if limit > 0 {
+		capacity = limit
+	}
+	columns := make([]column, len(cr.names))
+	for c, dtype := range cr.types {
+		columns[c] = newColumn(dtype, capacity)
+	}
+
+	n := 0
+	for limit <= 0 || n < limit {
+		var fields []string
+		var line int
+		if len(cr.pending) > 0 {
+			fields, line = cr.pending[0].fields, cr.pending[0].line
+			cr.pending = cr.pending[1:]
+		} else {
+			var err error
+			if fields, line, err = cr.next(); err == io.EOF {
+				break
+			} else if err != nil {
+				cr.err = err
+				return nil, 0, err
+			}
+		}
+
+		for c, value := range fields {
+			if err := cr.appendField(columns, c, value, n); err != nil {
+				cr.err = &CSVError{Line: line, Column: cr.names[c], Err: err}
+				return nil, 0, cr.err
+			}
+		}
+		n++
+	}
+
+	series := make([]*Series, len(columns))
+	for c, col := range columns {
+		series[c] = &Series{name: cr.names[c], dtype: cr.types[c], data: col}
+	}
+	return frameOf(series), n, nil
+}
+
+// appendField parses a field and appends it to column c, which holds row values so far, changing
+// the column's type where the field requires and allows it
+func (cr *CSVChunkReader) appendField(columns []column, c int, value string, row int) error {
+	if cr.nulls[value] {
+		columns[c].appendNull()
+		return nil
+	}
+
+	dtype := cr.types[c]
+	if dtype == Null && !cr.fixed[c] {
+		dtype = cr.inferValue(value)
+		retyped := newColumn(dtype, row+1)
+		for i := 0; i < row; i++ {
+			retyped.appendNull()
+		}
+		columns[c], cr.types[c] = retyped, dtype
+	}
+
+	parsed, ok := cr.parse(value, dtype)
+	if !ok && dtype == Int64 && !cr.fixed[c] {
+		if parsed, ok = cr.parse(value, Float64); ok {
+			columns[c] = (&Series{dtype: Int64, data: columns[c]}).asFloat64().data
+			cr.types[c] = Float64
+		}
+	}
+	if !ok {
+		return fmt.Errorf("cannot parse %q as %s", value, cr.types[c])
+	}
+	columns[c].appendValue(parsed)
+	return nil
+}
+
+// next reads the next record, returning its fields and the line of the input it starts on
+func (cr *CSVChunkReader) next() ([]string, int, error) {
+	fields, err := cr.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, 0, &CSVError{Line: parseErr.Line + cr.lineShift, Err: parseErr.Err}
+		}
+		return nil, 0, err
+	}
+
+	if cr.quote != '"' {
+		for i, field := range fields {
+			fields[i] = strings.Map(cr.swapQuote, field)
+		}
+	}
+	line, _ := cr.reader.FieldPos(0)
+	return fields, line + cr.lineShift, nil
+}
+
+// inferValue returns the narrowest type that can hold a field
+func (cr *CSVChunkReader) inferValue(value string) DType {
+	for _, dtype := range []DType{Int64, Float64, Datetime, Bool} {
+		if _, ok := cr.parse(value, dtype); ok {
+			return dtype
+		}
+	}
+	return String
+}
+
+// parse converts a field to a value of the given type
+func (cr *CSVChunkReader) parse(value string, dtype DType) (interface{}, bool) {
+	switch dtype {
+	case Int64:
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		return parsed, err == nil
+	case Float64:
+		if cr.decimal != '.' {
+			if strings.Contains(value, ".") {
+				return nil, false
+			}
+			value = strings.ReplaceAll(value, string(cr.decimal), ".")
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		return parsed, err == nil
+	case Bool:
+		parsed, err := strconv.ParseBool(value)
+		return parsed, err == nil
+	case Datetime:
+		for _, layout := range csvTimeLayouts {
+			if parsed, err := time.Parse(layout, value); err == nil {
+				return parsed, true
+			}
+		}
+		return nil, false
+	case String, Categorical:
+		return value, true
+	}
+	return nil, false
+}
+
+// swapQuote exchanges the custom quote character and '"'
+func (cr *CSVChunkReader) swapQuote(r rune) rune {
+	switch r {
+	case cr.quote:
+		return '"'
+	case '"':
+		return cr.quote
+	}
+	return r
+}
+
+// widenCSVType returns the type of a column holding values of both types
+func widenCSVType(a, b DType) DType {
+	switch {
+	case a == Null || a == b:
+		return b
+	case a.IsNumeric() && b.IsNumeric():
+		return Float64
+	}
+	return String
+}
+
+// quoteSwapper exchanges a custom ASCII quote character with '"' in the input, so that
+// encoding/csv, which only knows '"', treats the custom character as the quote
+type quoteSwapper struct {
+	r     io.Reader
+	quote byte
+}
+
+// Read reads from the underlying reader and swaps the quote characters
+func (q *quoteSwapper) Read(p []byte) (int, error) {
+	n, err := q.r.Read(p)
+	for i, b := range p[:n] {
+		switch b {
+		case q.quote:
+			p[i] = '"'
+		case '"':
+			p[i] = q.quote
+		}
+	}
+	return n, err
+}
+
+// CSVWriteOptions configures WriteCSV. Delimiter defaults to ','. QuoteAll quotes every field,
+// where otherwise only fields holding the delimiter, quotes or line breaks are quoted. Missing
+// values are written as NullString. FloatFormat is a fmt format such as "%.2f" for Float64 values,
+// which by default are written in the shortest form that reads back exactly, and TimeLayout
+// formats Datetime values, defaulting to time.RFC3339Nano. Header writes the column names first.
+type CSVWriteOptions struct {
+	Delimiter   rune
+	QuoteAll    bool
+	NullString  string
+	FloatFormat string
+	TimeLayout  string
+	Header      bool
+}
+
+// WriteCSV writes the DataFrame to w as CSV following RFC 4180, one row at a time, ending every
+// record with CRLF
+func (df *DataFrame) WriteCSV(w io.Writer, opts CSVWriteOptions) error {
+	if opts.Delimiter == 0 {
+		opts.Delimiter = ','
+	}
+	if opts.TimeLayout == "" {
+		opts.TimeLayout = time.RFC3339Nano
+	}
+
+	buffered := bufio.NewWriter(w)
+	writer := csv.NewWriter(buffered)
+	writer.Comma = opts.Delimiter
+	writer.UseCRLF = true
+	write := func(record []string) error {
+		// a lone empty field would be written as a blank line, which readers skip
+		if len(record) != 1 || record[0] != "" {
+			return writer.Write(record)
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return err
+		}
+		return writeQuoted(buffered, record, opts.Delimiter)
+	}
+	if opts.QuoteAll {
+		if opts.Delimiter == '"' || opts.Delimiter == '\r' || opts.Delimiter == '\n' {
+			return fmt.Errorf("invalid delimiter %q", opts.Delimiter)
+		}
+		write = func(record []string) error {
+			return writeQuoted(buffered, record, opts.Delimiter)
+		}
+	}
+
+	record := make([]string, len(df.columns))
+	if opts.Header {
+		if err := write(df.ColumnNames()); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < df.RowCount(); i++ {
+		for c, s := range df.columns {
+			record[c] = formatCSVField(s.Value(i), opts)
+		}
+		if err := write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	return buffered.Flush()
+}
+
+// formatCSVField returns the text written for a value
+func formatCSVField(value interface{}, opts CSVWriteOptions) string {
+	switch v := value.(type) {
+	case nil:
+		return opts.NullString
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		if opts.FloatFormat != "" {
+			return fmt.Sprintf(opts.FloatFormat, v)
+		}
+		return formatFloat(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.Format(opts.TimeLayout)
+	}
+	return fmt.Sprint(value)
+}
+
+// formatFloat returns the shortest text that reads back as v, switching to exponent notation for
+// very large and very small magnitudes as encoding/json does
+func formatFloat(v float64) string {
+	if abs := math.Abs(v); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		return strconv.FormatFloat(v, 'e', -1, 64)
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// quotedFieldReplacer doubles the quotes inside a quoted field and writes its line breaks as CRLF
+var quotedFieldReplacer = strings.NewReplacer(`"`, `""`, "\r\n", "\r\n", "\n", "\r\n")
+
+// writeQuoted writes a record with every field quoted, doubling the quotes inside fields
+func writeQuoted(w *bufio.Writer, record []string, delimiter rune) error {
+	for i, field := range record {
+		if i > 0 {
+			w.WriteRune(delimiter)
+		}
+		w.WriteByte('"')
+		quotedFieldReplacer.WriteString(w, field)
+		w.WriteByte('"')
+	}
+	_, err := w.WriteString("\r\n")
+	return err
 }
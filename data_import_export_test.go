@@ -0,0 +1,57 @@
+package dataframe
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteCSVSingleColumnRoundTrip(t *testing.T) {
+	s, err := NewSeries("text", []interface{}{"", nil, "a\"b", "line\nbreak"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	df, err := NewDataFrameFromSeries(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, quoteAll := range []bool{false, true} {
+		var buf bytes.Buffer
+		if err := df.WriteCSV(&buf, CSVWriteOptions{Header: true, QuoteAll: quoteAll}); err != nil {
+			t.Fatal(err)
+		}
+		// every record, and the line break inside the last field, ends in CRLF
+		written := buf.String()
+		if lines := strings.Count(written, "\n"); lines != 6 || strings.Count(written, "\r\n") != lines {
+			t.Errorf("QuoteAll=%v wrote %q, want 6 line breaks all written as CRLF", quoteAll, written)
+		}
+
+		back, err := ReadCSV(&buf, CSVOptions{Header: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// empty fields read back as missing values
+		want := [][]interface{}{{nil}, {nil}, {"a\"b"}, {"line\nbreak"}}
+		if got := rowValues(t, back); !reflect.DeepEqual(got, want) {
+			t.Errorf("QuoteAll=%v read back %v from %q, want %v", quoteAll, got, written, want)
+		}
+	}
+}
+
+func TestReadCSVInfersOnlyParsableBools(t *testing.T) {
+	df, err := ReadCSV(strings.NewReader("a,b\ntRUE,true\nFalse,F\n"), CSVOptions{Header: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]DType{"a": String, "b": Bool} {
+		s, err := df.Column(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.DType() != want {
+			t.Errorf("column %s inferred as %v, want %v", name, s.DType(), want)
+		}
+	}
+}
@@ -1,41 +1,58 @@
 package dataframe
 
 import (
+	"fmt"
 	"math"
 )
 
 // Function to transform data, such as scaling, normalization, encoding categorical variables, etc.
-func (df *DataFrame) TransformData() {
+// The transformed data is returned as a new DataFrame.
+func (df *DataFrame) TransformData() (*DataFrame, error) {
+	columns := make([]*Series, 0, len(df.columns))
+	seen := make(map[string]bool, len(df.columns))
+
 	// Loop through each column in the DataFrame
-	for _, columnName := range df.header {
-		columnData := df.columns[columnName]
+	for _, series := range df.columns {
+		transformed := []*Series{series}
 
 		// Perform data transformation based on column type
-		switch columnData[0].(type) {
-		case int, int64, float64:
+		switch series.dtype {
+		case Int64, Float64:
 			// Perform scaling or normalization on numeric columns
-			minVal, maxVal := df.getMinMaxValues(columnData)
-			df.scaleColumn(columnName, minVal, maxVal)
-		case string:
+			minVal, maxVal := df.getMinMaxValues(series)
+			transformed = []*Series{df.scaleColumn(series, minVal, maxVal)}
+		case String, Categorical:
 			// Perform encoding on categorical columns
-			df.encodeColumn(columnName)
+			transformed = df.encodeColumn(series)
+		}
+
+		for _, s := range transformed {
+			if seen[s.name] {
+				return nil, fmt.Errorf("column name '%s' already exists", s.name)
+			}
+			seen[s.name] = true
+			columns = append(columns, s)
 		}
 	}
+
+	return frameOf(columns), nil
 }
 
 // Function to calculate the minimum and maximum values in a numeric column
-func (df *DataFrame) getMinMaxValues(column []interface{}) (float64, float64) {
+func (df *DataFrame) getMinMaxValues(series *Series) (float64, float64) {
 	minVal := math.Inf(1)
 	maxVal := math.Inf(-1)
 
-	for _, value := range column {
-		if val, ok := value.(float64); ok {
-			if val < minVal {
-				minVal = val
-			}
-			if val > maxVal {
-				maxVal = val
-			}
+	values, valid, _ := series.float64s()
+	for i, val := range values {
+		if !valid.get(i) {
+			continue
+		}
+		if val < minVal {
+			minVal = val
+		}
+		if val > maxVal {
+			maxVal = val
 		}
 	}
 
@@ -43,45 +60,48 @@ func (df *DataFrame) getMinMaxValues(column []interface{}) (float64, float64) {
 }
 
 // Function to scale a numeric column to a range of [0, 1]
-func (df *DataFrame) scaleColumn(columnName string, minVal, maxVal float64) {
-	column := df.columns[columnName]
+func (df *DataFrame) scaleColumn(series *Series, minVal, maxVal float64) *Series {
+	values, valid, _ := series.float64s()
 
-	for i := 0; i < len(column); i++ {
-		if val, ok := column[i].(float64); ok {
-			scaledVal := (val - minVal) / (maxVal - minVal)
-			df.columns[columnName][i] = scaledVal
+	scaled := makeVector(make([]float64, len(values)), newBitmap(len(values), false))
+	for i, val := range values {
+		if valid.get(i) {
+			scaled.data[i] = (val - minVal) / (maxVal - minVal)
+			scaled.valid.set(i, true)
 		}
 	}
+	return &Series{name: series.name, dtype: Float64, data: scaled}
 }
 
 // Function to encode categorical column using one-hot encoding
-func (df *DataFrame) encodeColumn(columnName string) {
-	column := df.columns[columnName]
-	uniqueValues := make(map[interface{}]bool)
+func (df *DataFrame) encodeColumn(series *Series) []*Series {
+	column := series.data.(*vector[string])
 
-	// Get unique values in the column
-	for _, value := range column {
-		uniqueValues[value] = true
+	// Get unique values in the column in order of first appearance
+	uniqueValues := make([]string, 0)
+	seen := make(map[string]bool)
+	for i, value := range column.data {
+		if column.valid.get(i) && !seen[value] {
+			seen[value] = true
+			uniqueValues = append(uniqueValues, value)
+		}
 	}
 
 	// Create new columns for each unique value
-	for value := range uniqueValues {
-		newColumnName := columnName + "_" + value.(string)
-		encodedValues := make([]interface{}, len(column))
+	encoded := make([]*Series, 0, len(uniqueValues))
+	for _, value := range uniqueValues {
+		newColumnName := series.name + "_" + value
+		encodedValues := make([]int64, len(column.data))
 
 		// Encode the column values based on unique value presence
-		for i, val := range column {
-			if val == value {
+		for i, val := range column.data {
+			if column.valid.get(i) && val == value {
 				encodedValues[i] = 1
-			} else {
-				encodedValues[i] = 0
 			}
 		}
 
-		// Add the new encoded column to the DataFrame
-		df.AddColumn(newColumnName, encodedValues)
+		encoded = append(encoded, NewInt64Series(newColumnName, encodedValues))
 	}
 
-	// Remove the original categorical column from the DataFrame
-	df.RemoveColumn(columnName)
+	return encoded
 }
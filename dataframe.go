@@ -3,15 +3,17 @@ package dataframe
 import (
 	"errors"
 	"fmt"
-	"sort"
-	"strconv"
+	"math"
+	"slices"
 	"strings"
+	"time"
 )
 
-// DataFrame represents a data structure for storing tabular data
+// DataFrame represents a data structure for storing tabular data. A DataFrame is immutable: every
+// operation returns a new DataFrame, which shares the storage of any columns it leaves unchanged.
 type DataFrame struct {
-	header  []string
-	columns map[string][]interface{}
+	columns []*Series
+	index   map[string]int
 }
 
 // NewDataFrame creates a new DataFrame with the given column names
@@ -20,75 +22,120 @@ func NewDataFrame(columnNames []string) (*DataFrame, error) {
 		return nil, errors.New("column names are required")
 	}
 
-	columns := make(map[string][]interface{})
+	df := &DataFrame{index: make(map[string]int)}
 	for _, columnName := range columnNames {
-		if _, ok := columns[columnName]; ok {
+		if _, ok := df.index[columnName]; ok {
 			return nil, fmt.Errorf("column name '%s' already exists", columnName)
 		}
-		columns[columnName] = []interface{}{}
+		df.appendSeries(&Series{name: columnName, dtype: Null, data: nullColumn(0)})
 	}
 
-	return &DataFrame{
-		header:  columnNames,
-		columns: columns,
-	}, nil
+	return df, nil
 }
 
-// AddColumn adds a new column to the DataFrame
-func (df *DataFrame) AddColumn(name string, data []interface{}) error {
-	if len(data) != df.RowCount() {
-		return errors.New("data length does not match row count")
+// NewDataFrameFromSeries creates a new DataFrame from Series of equal length
+func NewDataFrameFromSeries(series ...*Series) (*DataFrame, error) {
+	if len(series) == 0 {
+		return nil, errors.New("at least one series is required")
+	}
+
+	df := &DataFrame{index: make(map[string]int)}
+	for _, s := range series {
+		if _, ok := df.index[s.name]; ok {
+			return nil, fmt.Errorf("column name '%s' already exists", s.name)
+		}
+		if s.Len() != series[0].Len() {
+			return nil, fmt.Errorf("column '%s' has %d rows, expected %d", s.name, s.Len(), series[0].Len())
+		}
+		df.appendSeries(s)
 	}
 
-	if _, ok := df.columns[name]; ok {
-		return fmt.Errorf("column name '%s' already exists", name)
+	return df, nil
+}
+
+// appendSeries adds a Series to the end of the column order
+func (df *DataFrame) appendSeries(s *Series) {
+	df.index[s.name] = len(df.columns)
+	df.columns = append(df.columns, s)
+}
+
+// withColumn returns a copy of the DataFrame with the column at position i replaced
+func (df *DataFrame) withColumn(i int, s *Series) *DataFrame {
+	columns := make([]*Series, len(df.columns))
+	copy(columns, df.columns)
+	columns[i] = s
+	return frameOf(columns)
+}
+
+// Clone returns a deep copy of the DataFrame that shares no storage with it
+func (df *DataFrame) Clone() *DataFrame {
+	columns := make([]*Series, len(df.columns))
+	for i, s := range df.columns {
+		columns[i] = &Series{name: s.name, dtype: s.dtype, data: s.data.Clone()}
 	}
+	return frameOf(columns)
+}
 
-	df.columns[name] = data
-	return nil
+// Column returns the Series stored under the given column name
+func (df *DataFrame) Column(name string) (*Series, error) {
+	i, ok := df.index[name]
+	if !ok {
+		return nil, fmt.Errorf("column '%s' does not exist", name)
+	}
+	return df.columns[i], nil
 }
 
-// ModifyColumn modifies an existing column in the DataFrame
-func (df *DataFrame) ModifyColumn(name string, data []interface{}) error {
+// AddColumn returns a new DataFrame with a column added after the existing ones
+func (df *DataFrame) AddColumn(name string, data []interface{}) (*DataFrame, error) {
 	if len(data) != df.RowCount() {
-		return errors.New("data length does not match row count")
+		return nil, errors.New("data length does not match row count")
 	}
 
-	if _, ok := df.columns[name]; !ok {
-		return fmt.Errorf("column '%s' does not exist", name)
+	if _, ok := df.index[name]; ok {
+		return nil, fmt.Errorf("column name '%s' already exists", name)
 	}
 
-	df.columns[name] = data
-	return nil
+	s, err := NewSeries(name, data)
+	if err != nil {
+		return nil, err
+	}
+	return frameOf(append(df.columns[:len(df.columns):len(df.columns)], s)), nil
 }
 
-// ChangeColumnOrder changes the order of columns in the DataFrame
-func (df *DataFrame) ChangeColumnOrder(newOrder []string) error {
-	if len(newOrder) != len(df.header) {
-		return errors.New("invalid column order")
+// ModifyColumn returns a new DataFrame with the values of an existing column replaced; the new
+// values must match the column's type unless the column has no type yet
+func (df *DataFrame) ModifyColumn(name string, data []interface{}) (*DataFrame, error) {
+	if len(data) != df.RowCount() {
+		return nil, errors.New("data length does not match row count")
 	}
 
-	// Check if all new column names exist in the DataFrame
-	columnExists := make(map[string]bool)
-	for _, columnName := range df.header {
-		columnExists[columnName] = true
-	}
-	for _, columnName := range newOrder {
-		if _, ok := columnExists[columnName]; !ok {
-			return fmt.Errorf("column '%s' does not exist", columnName)
-		}
+	i, ok := df.index[name]
+	if !ok {
+		return nil, fmt.Errorf("column '%s' does not exist", name)
 	}
 
-	// Rearrange the columns based on the new order
-	newColumns := make(map[string][]interface{})
-	for _, columnName := range newOrder {
-		newColumns[columnName] = df.columns[columnName]
+	var (
+		s   *Series
+		err error
+	)
+	if dtype := df.columns[i].dtype; dtype != Null {
+		s, err = NewTypedSeries(name, dtype, data)
+	} else {
+		s, err = NewSeries(name, data)
 	}
+	if err != nil {
+		return nil, err
+	}
+	return df.withColumn(i, s), nil
+}
 
-	df.header = newOrder
-	df.columns = newColumns
+// ChangeColumnOrder returns a new DataFrame with the columns in the given order
+func (df *DataFrame) ChangeColumnOrder(newOrder []string) (*DataFrame, error) {
+	if len(newOrder) != len(df.columns) {
+		return nil, errors.New("invalid column order")
+	}
 
-	return nil
+	return df.Select(newOrder...)
 }
 
 // RowCount returns the number of rows in the DataFrame
@@ -97,18 +144,22 @@ func (df *DataFrame) RowCount() int {
 		return 0
 	}
 
-	return len(df.columns[df.header[0]])
+	return df.columns[0].Len()
 }
 
 // ColumnNames returns the names of the columns in the DataFrame
 func (df *DataFrame) ColumnNames() []string {
-	return df.header
+	names := make([]string, len(df.columns))
+	for i, s := range df.columns {
+		names[i] = s.name
+	}
+	return names
 }
 
 // PrintHeader prints the header of the DataFrame
 func (df *DataFrame) PrintHeader() {
-	for _, columnName := range df.header {
-		fmt.Printf("%v\t", columnName)
+	for _, s := range df.columns {
+		fmt.Printf("%v\t", s.name)
 	}
 	fmt.Println()
 }
@@ -116,367 +167,381 @@ func (df *DataFrame) PrintHeader() {
 // PrintData prints the data in the DataFrame
 func (df *DataFrame) PrintData() {
 	for i := 0; i < df.RowCount(); i++ {
-		for _, columnName := range df.header {
-			fmt.Printf("%v\t", df.columns[columnName][i])
+		for _, s := range df.columns {
+			fmt.Printf("%v\t", s.Value(i))
 		}
 		fmt.Println()
 	}
 }
 
+// take returns a new DataFrame holding the rows at the given indices of every column
+func (df *DataFrame) take(indices []int) *DataFrame {
+	columns := make([]*Series, len(df.columns))
+	for i, s := range df.columns {
+		columns[i] = s.take(indices)
+	}
+	return frameOf(columns)
+}
+
 // Filter applies a filter to the DataFrame based on a given condition
 func (df *DataFrame) Filter(condition func(row int) bool) (*DataFrame, error) {
-	filteredColumns := make(map[string][]interface{})
-	for columnName, columnData := range df.columns {
-		filteredColumns[columnName] = make([]interface{}, 0)
-		for i := 0; i < df.RowCount(); i++ {
-			if condition(i) {
-				filteredColumns[columnName] = append(filteredColumns[columnName], columnData[i])
-			}
+	if len(df.columns) == 0 {
+		return nil, errors.New("no columns matched the filter condition")
+	}
+
+	indices := make([]int, 0)
+	for i := 0; i < df.RowCount(); i++ {
+		if condition(i) {
+			indices = append(indices, i)
 		}
 	}
 
-	if len(filteredColumns) == 0 {
-		return nil, errors.New("no columns matched the filter condition")
+	return df.take(indices), nil
+}
+
+// FilterMask returns the rows of the DataFrame whose entry in the mask is true
+func (df *DataFrame) FilterMask(mask []bool) (*DataFrame, error) {
+	if len(mask) != df.RowCount() {
+		return nil, errors.New("mask length does not match row count")
 	}
 
-	return &DataFrame{
-		header:  df.header,
-		columns: filteredColumns,
-	}, nil
+	return df.Filter(func(row int) bool {
+		return mask[row]
+	})
 }
 
 // Count returns the number of non-nil values in a column
 func (df *DataFrame) Count(columnName string) (int, error) {
-	columnData, ok := df.columns[columnName]
+	s, err := df.Column(columnName)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.Len() - s.NullCount(), nil
+}
+
+// AggOption configures how aggregate functions treat missing values
+type AggOption func(*aggConfig)
+
+// aggConfig holds the settings applied by AggOptions
+type aggConfig struct {
+	propagateNulls bool
+}
+
+// PropagateNulls makes an aggregate return NaN when any input value is missing instead of skipping it
+func PropagateNulls() AggOption {
+	return func(c *aggConfig) {
+		c.propagateNulls = true
+	}
+}
+
+// newAggConfig applies the given options to the default configuration
+func newAggConfig(opts []AggOption) aggConfig {
+	var c aggConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// numericColumn returns the non-missing values of a numeric column as float64s and whether any value was missing
+func (df *DataFrame) numericColumn(columnName string) ([]float64, bool, error) {
+	s, err := df.Column(columnName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	values, valid, ok := s.float64s()
 	if !ok {
-		return 0, fmt.Errorf("column '%s' does not exist", columnName)
+		return nil, false, fmt.Errorf("column '%s' is not numeric", columnName)
+	}
+	if s.NullCount() == 0 {
+		return values, false, nil
 	}
 
-	count := 0
-	for _, value := range columnData {
-		if value != nil {
-			count++
+	present := make([]float64, 0, len(values)-s.NullCount())
+	for i, value := range values {
+		if valid.get(i) {
+			present = append(present, value)
 		}
 	}
-	return count, nil
+	return present, true, nil
 }
 
-// Sum returns the sum of values in a numeric column
-func (df *DataFrame) Sum(columnName string) (float64, error) {
-	columnData, ok := df.columns[columnName]
+// numericPair returns the values of two numeric columns for the rows where both are present
+// and whether any row was dropped because of a missing value
+func (df *DataFrame) numericPair(column1, column2 string) ([]float64, []float64, bool, error) {
+	series1, err := df.Column(column1)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	series2, err := df.Column(column2)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	values1, valid1, ok := series1.float64s()
+	if !ok {
+		return nil, nil, false, fmt.Errorf("column '%s' is not numeric", column1)
+	}
+	values2, valid2, ok := series2.float64s()
 	if !ok {
-		return 0, fmt.Errorf("column '%s' does not exist", columnName)
+		return nil, nil, false, fmt.Errorf("column '%s' is not numeric", column2)
 	}
 
-	sum := 0.0
-	for _, value := range columnData {
-		if numericValue, ok := value.(float64); ok {
-			sum += numericValue
-		} else {
-			return 0, fmt.Errorf("column '%s' is not numeric", columnName)
+	x := make([]float64, 0, len(values1))
+	y := make([]float64, 0, len(values2))
+	for i := range values1 {
+		if valid1.get(i) && valid2.get(i) {
+			x = append(x, values1[i])
+			y = append(y, values2[i])
 		}
 	}
-	return sum, nil
+	return x, y, len(x) < len(values1), nil
 }
 
-// Mean returns the mean (average) of values in a numeric column
-func (df *DataFrame) Mean(columnName string) (float64, error) {
-	count, err := df.Count(columnName)
+// Sum returns the sum of values in a numeric column, skipping missing values unless PropagateNulls is given
+func (df *DataFrame) Sum(columnName string, opts ...AggOption) (float64, error) {
+	values, hasNull, err := df.numericColumn(columnName)
 	if err != nil {
 		return 0, err
 	}
+	if hasNull && newAggConfig(opts).propagateNulls {
+		return math.NaN(), nil
+	}
 
-	sum, err := df.Sum(columnName)
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum, nil
+}
+
+// Mean returns the mean (average) of values in a numeric column, skipping missing values unless PropagateNulls is given
+func (df *DataFrame) Mean(columnName string, opts ...AggOption) (float64, error) {
+	values, hasNull, err := df.numericColumn(columnName)
 	if err != nil {
 		return 0, err
 	}
+	if hasNull && newAggConfig(opts).propagateNulls {
+		return math.NaN(), nil
+	}
 
-	if count == 0 {
+	if len(values) == 0 {
 		return 0, errors.New("no values in column")
 	}
 
-	mean := sum / float64(count)
-	return mean, nil
-}
-
-// Sort sorts the DataFrame based on one or more columns in ascending or descending order
-func (df *DataFrame) Sort(columns []string, ascending bool) error {
-	sort.SliceStable(df.columns[df.header[0]], func(i, j int) bool {
-		for _, col := range columns {
-			if df.columns[col][i] != df.columns[col][j] {
-				switch df.columns[col][i].(type) {
-				case int:
-					if ascending {
-						return df.columns[col][i].(int) < df.columns[col][j].(int)
-					} else {
-						return df.columns[col][i].(int) > df.columns[col][j].(int)
-					}
-				case float64:
-					if ascending {
-						return df.columns[col][i].(float64) < df.columns[col][j].(float64)
-					} else {
-						return df.columns[col][i].(float64) > df.columns[col][j].(float64)
-					}
-				case string:
-					if ascending {
-						return strings.Compare(df.columns[col][i].(string), df.columns[col][j].(string)) < 0
-					} else {
-						return strings.Compare(df.columns[col][i].(string), df.columns[col][j].(string)) > 0
-					}
-				}
-			}
-		}
-		return i < j
-	})
-
-	return nil
+	return mean(values), nil
 }
 
-// GroupBy groups the DataFrame by one or more columns
-func (df *DataFrame) GroupBy(columns []string) (*DataFrame, error) {
-	groupedColumns := make(map[string][]interface{})
-	groupedRowCount := make(map[string]int)
-
-	for _, col := range columns {
-		if _, ok := df.columns[col]; !ok {
-			return nil, fmt.Errorf("column '%s' does not exist", col)
-		}
-		groupedColumns[col] = []interface{}{}
-		groupedRowCount[col] = 0
+// mean returns the arithmetic mean of a non-empty slice
+func mean(values []float64) float64 {
+	sum := 0.0
+	for _, value := range values {
+		sum += value
 	}
+	return sum / float64(len(values))
+}
 
-	for i := 0; i < df.RowCount(); i++ {
-		groupKey := ""
-		for _, col := range columns {
-			groupKey += fmt.Sprintf("%v-%v", col, df.columns[col][i])
-		}
-
-		if _, ok := groupedColumns[groupKey]; !ok {
-			for _, col := range columns {
-				groupedColumns[col] = append(groupedColumns[col], df.columns[col][i])
-			}
-		}
-		groupedRowCount[groupKey]++
+// variance returns the sample variance of a slice holding at least two values
+func variance(values []float64) float64 {
+	avg := mean(values)
+	sum := 0.0
+	for _, value := range values {
+		sum += (value - avg) * (value - avg)
 	}
+	return sum / float64(len(values)-1)
+}
 
-	for col, rowCount := range groupedRowCount {
-		for i := 0; i < df.RowCount(); i++ {
-			if groupedRowCount[fmt.Sprintf("%v-%v", col, df.columns[col][i])] > 0 {
-				groupedColumns[col] = append(groupedColumns[col], rowCount)
-				groupedRowCount[fmt.Sprintf("%v-%v", col, df.columns[col][i])] = 0
-			} else {
-				groupedColumns[col] = append(groupedColumns[col], nil)
-			}
-		}
+// SortKey describes one column of a sort order and where its missing values are placed
+type SortKey struct {
+	Col        string
+	Descending bool
+	NullsFirst bool
+}
+
+// Sort returns a new DataFrame with its rows ordered by the given keys; rows that compare equal keep their relative order
+func (df *DataFrame) Sort(keys []SortKey) (*DataFrame, error) {
+	order, err := df.ArgSort(keys)
+	if err != nil {
+		return nil, err
 	}
 
-	return &DataFrame{
-		header:  append(columns, "Count"),
-		columns: groupedColumns,
-	}, nil
+	return df.take(order), nil
 }
 
-// Join joins multiple DataFrames based on common columns
-func Join(dataFrames []*DataFrame, joinColumns []string) (*DataFrame, error) {
-	if len(dataFrames) == 0 {
-		return nil, errors.New("no DataFrames provided for join")
+// ArgSort returns the row indices that would order the DataFrame by the given keys
+func (df *DataFrame) ArgSort(keys []SortKey) ([]int, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one sort key is required")
 	}
 
-	joinedColumns := make(map[string][]interface{})
-	for _, df := range dataFrames {
-		for _, col := range df.header {
-			if _, ok := joinedColumns[col]; ok {
-				return nil, fmt.Errorf("column '%s' already exists in the join result", col)
-			}
-			joinedColumns[col] = df.columns[col]
+	type sortColumn struct {
+		SortKey
+		series  *Series
+		compare func(i, j int) int
+	}
+	columns := make([]sortColumn, len(keys))
+	for k, key := range keys {
+		s, err := df.Column(key.Col)
+		if err != nil {
+			return nil, err
 		}
+		columns[k] = sortColumn{SortKey: key, series: s, compare: s.comparator()}
 	}
 
-	for _, df := range dataFrames[1:] {
-		for i := 0; i < df.RowCount(); i++ {
-			rowMatch := make([]bool, len(dataFrames[0].header))
-			for j, col := range joinColumns {
-				if _, ok := df.columns[col]; !ok {
-					return nil, fmt.Errorf("column '%s' does not exist in DataFrame for join", col)
-				}
-
-				if _, ok := joinedColumns[col]; !ok {
-					return nil, fmt.Errorf("column '%s' does not exist in the join result", col)
-				}
-
-				if df.columns[col][i] != nil {
-					if idx := findIndex(joinedColumns[col], df.columns[col][i]); idx != -1 {
-						rowMatch[idx] = true
-					}
+	return df.argSortFunc(func(a, b int) int {
+		for _, c := range columns {
+			aNull, bNull := c.series.IsNull(a), c.series.IsNull(b)
+			switch {
+			case aNull && bNull:
+				continue
+			case aNull != bNull:
+				if aNull == c.NullsFirst {
+					return -1
 				}
+				return 1
 			}
 
-			if allTrue(rowMatch) {
-				for _, col := range df.header {
-					if _, ok := joinedColumns[col]; !ok {
-						return nil, fmt.Errorf("column '%s' does not exist in the join result", col)
-					}
-
-					joinedColumns[col] = append(joinedColumns[col], df.columns[col][i])
+			if result := c.compare(a, b); result != 0 {
+				if c.Descending {
+					return -result
 				}
+				return result
 			}
 		}
+		return 0
+	}), nil
+}
+
+// SortBy returns a new DataFrame with its rows ordered by a comparator over row indices, which must
+// return a negative number when row a sorts before row b, a positive number when it sorts after,
+// and zero to keep their relative order
+func (df *DataFrame) SortBy(compare func(a, b int) int) (*DataFrame, error) {
+	if compare == nil {
+		return nil, errors.New("comparator is required")
 	}
 
-	return &DataFrame{
-		header:  dataFrames[0].header,
-		columns: joinedColumns,
-	}, nil
+	return df.take(df.argSortFunc(compare)), nil
 }
 
-// CleanData cleans the DataFrame by handling missing values, duplicates, and data type conversion
-func (df *DataFrame) CleanData() error {
-	// Handle missing values
-	for columnName, columnData := range df.columns {
-		for i := 0; i < len(columnData); i++ {
-			if columnData[i] == nil {
-				switch df.columns[columnName][0].(type) {
-				case int:
-					df.columns[columnName][i] = 0
-				case float64:
-					df.columns[columnName][i] = 0.0
-				case string:
-					df.columns[columnName][i] = ""
-				default:
-					return fmt.Errorf("unknown data type in column '%s'", columnName)
-				}
-			}
-		}
+// argSortFunc stably sorts the row indices of the DataFrame with the given comparator
+func (df *DataFrame) argSortFunc(compare func(a, b int) int) []int {
+	order := make([]int, df.RowCount())
+	for i := range order {
+		order[i] = i
 	}
+	slices.SortStableFunc(order, compare)
+	return order
+}
 
-	// Handle duplicates
-	duplicateIndexes := make(map[int]bool)
-	for i := 0; i < df.RowCount(); i++ {
-		if duplicateIndexes[i] {
+// CleanData returns a cleaned copy of the DataFrame with missing values, duplicates, and data type conversion handled
+func (df *DataFrame) CleanData() (*DataFrame, error) {
+	// Handle missing values
+	columns := make([]*Series, len(df.columns))
+	for i, s := range df.columns {
+		columns[i] = s
+		if s.NullCount() == 0 {
 			continue
 		}
-		for j := i + 1; j < df.RowCount(); j++ {
-			if duplicateIndexes[j] {
-				continue
-			}
-			isDuplicate := true
-			for _, columnName := range df.header {
-				if df.columns[columnName][i] != df.columns[columnName][j] {
-					isDuplicate = false
-					break
-				}
-			}
-			if isDuplicate {
-				duplicateIndexes[j] = true
-			}
+		filled, err := fillZero(s)
+		if err != nil {
+			return nil, err
 		}
+		columns[i] = filled
 	}
+	df = frameOf(columns)
 
-	for columnName, columnData := range df.columns {
-		cleanedColumn := make([]interface{}, 0)
-		for i := 0; i < len(columnData); i++ {
-			if !duplicateIndexes[i] {
-				cleanedColumn = append(cleanedColumn, columnData[i])
-			}
-		}
-		df.columns[columnName] = cleanedColumn
-	}
-
-	return nil
+	// Handle duplicates
+	return df.DropDuplicates(nil, KeepFirst)
 }
 
-// Variance calculates the variance of values in a numeric column
-func (df *DataFrame) Variance(columnName string) (float64, error) {
-	columnData, ok := df.columns[columnName]
-	if !ok {
-		return 0, fmt.Errorf("column '%s' does not exist", columnName)
-	}
+// fillZero returns a copy of the Series with missing values replaced by the zero value of its type
+func fillZero(s *Series) (*Series, error) {
+	var zero interface{}
+	switch s.dtype {
+	case Int64:
+		zero = int64(0)
+	case Float64:
+		zero = 0.0
+	case String, Categorical:
+		zero = ""
+	case Bool:
+		zero = false
+	case Datetime:
+		zero = time.Time{}
+	default:
+		return nil, fmt.Errorf("unknown data type in column '%s'", s.name)
+	}
+
+	filled, _ := s.data.fillNull(zero)
+	return &Series{name: s.name, dtype: s.dtype, data: filled}, nil
+}
 
-	count, err := df.Count(columnName)
+// Variance calculates the variance of values in a numeric column, skipping missing values unless PropagateNulls is given
+func (df *DataFrame) Variance(columnName string, opts ...AggOption) (float64, error) {
+	values, hasNull, err := df.numericColumn(columnName)
 	if err != nil {
 		return 0, err
 	}
-
-	mean, err := df.Mean(columnName)
-	if err != nil {
-		return 0, err
+	if hasNull && newAggConfig(opts).propagateNulls {
+		return math.NaN(), nil
 	}
 
+	count := len(values)
 	if count <= 1 {
 		return 0, errors.New("insufficient data points for variance calculation")
 	}
 
-	variance := 0.0
-	for _, value := range columnData {
-		if numericValue, ok := value.(float64); ok {
-			variance += (numericValue - mean) * (numericValue - mean)
-		} else {
-			return 0, fmt.Errorf("column '%s' is not numeric", columnName)
-		}
-	}
-	variance /= float64(count - 1)
-
-	return variance, nil
+	return variance(values), nil
 }
 
-// StandardDeviation calculates the standard deviation of values in a numeric column
-func (df *DataFrame) StandardDeviation(columnName string) (float64, error) {
-	variance, err := df.Variance(columnName)
+// StandardDeviation calculates the standard deviation of values in a numeric column, skipping missing values unless PropagateNulls is given
+func (df *DataFrame) StandardDeviation(columnName string, opts ...AggOption) (float64, error) {
+	variance, err := df.Variance(columnName, opts...)
 	if err != nil {
 		return 0, err
 	}
 
 	standardDeviation := 0.0
-	if variance > 0 {
-		standardDeviation = �math.Sqrt(variance)
+	if variance > 0 || math.IsNaN(variance) {
+		standardDeviation = math.Sqrt(variance)
 	}
 
 	return standardDeviation, nil
 }
 
-// Correlation calculates the correlation coefficient between two numeric columns
-func (df *DataFrame) Correlation(column1, column2 string) (float64, error) {
-	column1Data, ok1 := df.columns[column1]
-	column2Data, ok2 := df.columns[column2]
-
-	if !ok1 {
-		return 0, fmt.Errorf("column '%s' does not exist", column1)
-	}
-	if !ok2 {
-		return 0, fmt.Errorf("column '%s' does not exist", column2)
-	}
-
-	count, err := df.Count(column1)
+// Correlation calculates the correlation coefficient between two numeric columns over the rows where
+// both values are present, unless PropagateNulls is given
+func (df *DataFrame) Correlation(column1, column2 string, opts ...AggOption) (float64, error) {
+	column1Data, column2Data, hasNull, err := df.numericPair(column1, column2)
 	if err != nil {
 		return 0, err
 	}
+	if hasNull && newAggConfig(opts).propagateNulls {
+		return math.NaN(), nil
+	}
 
+	count := float64(len(column1Data))
 	if count <= 1 {
 		return 0, errors.New("insufficient data points for correlation calculation")
 	}
 
 	var (
-		sumXY    float64
-		sumX     float64
-		sumY     float64
+		sumXY      float64
+		sumX       float64
+		sumY       float64
 		sumXSquare float64
 		sumYSquare float64
 	)
 
-	for i := 0; i < df.RowCount(); i++ {
-		if value1, ok := column1Data[i].(float64); ok {
-			if value2, ok := column2Data[i].(float64); ok {
-				sumXY += value1 * value2
-				sumX += value1
-				sumY += value2
-				sumXSquare += value1 * value1
-				sumYSquare += value2 * value2
-			} else {
-				return 0, fmt.Errorf("column '%s' is not numeric", column2)
-			}
-		} else {
-			return 0, fmt.Errorf("column '%s' is not numeric", column1)
-		}
+	for i := range column1Data {
+		value1, value2 := column1Data[i], column2Data[i]
+		sumXY += value1 * value2
+		sumX += value1
+		sumY += value2
+		sumXSquare += value1 * value1
+		sumYSquare += value2 * value2
 	}
 
 	numerator := count*sumXY - sumX*sumY
@@ -490,23 +555,18 @@ func (df *DataFrame) Correlation(column1, column2 string) (float64, error) {
 	return correlation, nil
 }
 
-// Covariance calculates the covariance between two numeric columns
-func (df *DataFrame) Covariance(column1, column2 string) (float64, error) {
-	column1Data, ok1 := df.columns[column1]
-	column2Data, ok2 := df.columns[column2]
-
-	if !ok1 {
-		return 0, fmt.Errorf("column '%s' does not exist", column1)
-	}
-	if !ok2 {
-		return 0, fmt.Errorf("column '%s' does not exist", column2)
-	}
-
-	count, err := df.Count(column1)
+// Covariance calculates the covariance between two numeric columns over the rows where
+// both values are present, unless PropagateNulls is given
+func (df *DataFrame) Covariance(column1, column2 string, opts ...AggOption) (float64, error) {
+	column1Data, column2Data, hasNull, err := df.numericPair(column1, column2)
 	if err != nil {
 		return 0, err
 	}
+	if hasNull && newAggConfig(opts).propagateNulls {
+		return math.NaN(), nil
+	}
 
+	count := len(column1Data)
 	if count <= 1 {
 		return 0, errors.New("insufficient data points for covariance calculation")
 	}
@@ -517,96 +577,34 @@ func (df *DataFrame) Covariance(column1, column2 string) (float64, error) {
 		sumY  float64
 	)
 
-	for i := 0; i < df.RowCount(); i++ {
-		if value1, ok := column1Data[i].(float64); ok {
-			if value2, ok := column2Data[i].(float64); ok {
-				sumXY += value1 * value2
-				sumX += value1
-				sumY += value2
-			} else {
-				return 0, fmt.Errorf("column '%s' is not numeric", column2)
-			}
-		} else {
-			return 0, fmt.Errorf("column '%s' is not numeric", column1)
-		}
+	for i := range column1Data {
+		value1, value2 := column1Data[i], column2Data[i]
+		sumXY += value1 * value2
+		sumX += value1
+		sumY += value2
 	}
 
-	covariance := 0.0
-	if count > 1 {
-		meanX := sumX / float64(count)
-		meanY := sumY / float64(count)
-		covariance = (sumXY - float64(count)*meanX*meanY) / float64(count-1)
-	}
+	meanX := sumX / float64(count)
+	meanY := sumY / float64(count)
+	covariance := (sumXY - float64(count)*meanX*meanY) / float64(count-1)
 
 	return covariance, nil
 }
 
-// SerializeToJSON serializes the DataFrame to a JSON string
+// SerializeToJSON serializes the DataFrame to a JSON string holding an array of row objects
 func (df *DataFrame) SerializeToJSON() (string, error) {
-	jsonData := "[\n"
-
-	for i := 0; i < df.RowCount(); i++ {
-		jsonData += "\t{"
-		for j, columnName := range df.header {
-			jsonData += fmt.Sprintf("\"%s\":", columnName)
-			if value, ok := df.columns[columnName][i].(string); ok {
-				jsonData += "\"" + value + "\""
-			} else {
-				jsonData += fmt.Sprintf("%v", df.columns[columnName][i])
-			}
-			if j < len(df.header)-1 {
-				jsonData += ","
-			}
-		}
-		jsonData += "}"
-		if i < df.RowCount()-1 {
-			jsonData += ","
-		}
-		jsonData += "\n"
+	var b strings.Builder
+	if err := df.WriteJSON(&b, JSONOptions{Orient: OrientRecords}); err != nil {
+		return "", err
 	}
-
-	jsonData += "]\n"
-
-	return jsonData, nil
+	return b.String(), nil
 }
 
-// SerializeToCSV serializes the DataFrame to a CSV string
+// SerializeToCSV serializes the DataFrame to a CSV string with a header line
 func (df *DataFrame) SerializeToCSV() (string, error) {
-	csvData := strings.Join(df.header, ",") + "\n"
-
-	for i := 0; i < df.RowCount(); i++ {
-		for j, columnName := range df.header {
-			if value, ok := df.columns[columnName][i].(string); ok {
-				csvData += "\"" + value + "\""
-			} else {
-				csvData += fmt.Sprintf("%v", df.columns[columnName][i])
-			}
-			if j < len(df.header)-1 {
-				csvData += ","
-			}
-		}
-		csvData += "\n"
-	}
-
-	return csvData, nil
-}
-
-// findIndex returns the index of an element in a slice, or -1 if not found
-func findIndex(slice []interface{}, element interface{}) int {
-	for i, value := range slice {
-		if value == element {
-			return i
-		}
-	}
-	return -1
-}
-
-// allTrue checks if all elements in a bool slice are true
-func allTrue(slice []bool) bool {
-	for _, value := range slice {
-		if !value {
-			return false
-		}
+	var b strings.Builder
+	if err := df.WriteCSV(&b, CSVWriteOptions{Header: true}); err != nil {
+		return "", err
 	}
-	return true
+	return b.String(), nil
 }
@@ -0,0 +1,146 @@
+package dataframe
+
+import (
+	"fmt"
+	"time"
+)
+
+// DType identifies the logical type of the values stored in a column
+type DType int
+
+const (
+	// Null is the type of a column whose values are all missing and whose type is not yet known
+	Null DType = iota
+	// Int64 columns hold signed 64-bit integers
+	Int64
+	// Float64 columns hold 64-bit floating point numbers
+	Float64
+	// String columns hold arbitrary text
+	String
+	// Bool columns hold true/false values
+	Bool
+	// Datetime columns hold time.Time values
+	Datetime
+	// Categorical columns hold text drawn from a limited set of labels
+	Categorical
+)
+
+// DefaultInferRows is the number of leading rows examined when inferring a column type
+const DefaultInferRows = 100
+
+// String returns the name of the type
+func (t DType) String() string {
+	switch t {
+	case Null:
+		return "Null"
+	case Int64:
+		return "Int64"
+	case Float64:
+		return "Float64"
+	case String:
+		return "String"
+	case Bool:
+		return "Bool"
+	case Datetime:
+		return "Datetime"
+	case Categorical:
+		return "Categorical"
+	}
+	return fmt.Sprintf("DType(%d)", int(t))
+}
+
+// parseDType returns the type with the given name, as returned by String
+func parseDType(name string) (DType, bool) {
+	for t := Null; t <= Categorical; t++ {
+		if t.String() == name {
+			return t, true
+		}
+	}
+	return Null, false
+}
+
+// IsNumeric reports whether the type holds numbers
+func (t DType) IsNumeric() bool {
+	return t == Int64 || t == Float64
+}
+
+// TypeMismatchError reports a value that cannot be stored in a column of the expected type
+type TypeMismatchError struct {
+	Column   string
+	Row      int
+	Expected DType
+	Value    interface{}
+}
+
+// Error returns a description of the mismatch
+func (e *TypeMismatchError) Error() string {
+	return fmt.Sprintf("column '%s' expects %s but row %d holds %v (%T)", e.Column, e.Expected, e.Row, e.Value, e.Value)
+}
+
+// dtypeOf returns the natural column type for a boxed Go value
+func dtypeOf(x interface{}) (DType, bool) {
+	switch {
+	case isInteger(x):
+		return Int64, true
+	case isFloat(x):
+		return Float64, true
+	}
+	switch x.(type) {
+	case string:
+		return String, true
+	case bool:
+		return Bool, true
+	case time.Time:
+		return Datetime, true
+	}
+	return Null, false
+}
+
+// InferDType infers the column type of boxed values by examining the first sampleRows rows,
+// continuing past the sample only while every value seen so far is nil. Integers mixed with
+// floats infer as Float64. A sampleRows of zero or less examines every row.
+func InferDType(values []interface{}, sampleRows int) (DType, error) {
+	if sampleRows <= 0 || sampleRows > len(values) {
+		sampleRows = len(values)
+	}
+
+	inferred := Null
+	for i, value := range values {
+		if i >= sampleRows && inferred != Null {
+			break
+		}
+		if value == nil {
+			continue
+		}
+		t, ok := dtypeOf(value)
+		if !ok {
+			return Null, fmt.Errorf("unsupported value type %T at row %d", value, i)
+		}
+		switch {
+		case inferred == Null || inferred == t:
+			inferred = t
+		case inferred.IsNumeric() && t.IsNumeric():
+			inferred = Float64
+		default:
+			return Null, &TypeMismatchError{Row: i, Expected: inferred, Value: value}
+		}
+	}
+	return inferred, nil
+}
+
+// newColumn returns an empty column with storage for the given type
+func newColumn(dtype DType, capacity int) column {
+	switch dtype {
+	case Int64:
+		return makeVector(make([]int64, 0, capacity), make(bitmap, 0, (capacity+63)/64))
+	case Float64:
+		return makeVector(make([]float64, 0, capacity), make(bitmap, 0, (capacity+63)/64))
+	case String, Categorical:
+		return makeVector(make([]string, 0, capacity), make(bitmap, 0, (capacity+63)/64))
+	case Bool:
+		return makeVector(make([]bool, 0, capacity), make(bitmap, 0, (capacity+63)/64))
+	case Datetime:
+		return makeVector(make([]time.Time, 0, capacity), make(bitmap, 0, (capacity+63)/64))
+	}
+	return makeVector(make([]null, 0, capacity), make(bitmap, 0, (capacity+63)/64))
+}
@@ -0,0 +1,93 @@
+package dataframe
+
+import "fmt"
+
+// Keep selects which rows of a set of duplicates DropDuplicates keeps
+type Keep int
+
+const (
+	// KeepFirst keeps the first row of each set of duplicates
+	KeepFirst Keep = iota
+	// KeepLast keeps the last row of each set of duplicates
+	KeepLast
+	// KeepNone drops every row that has a duplicate
+	KeepNone
+)
+
+// Duplicated returns a mask marking every row whose values in the subset columns, or in all
+// columns when subset is empty, equal those of an earlier row. Missing values equal each other,
+// and so do NaNs.
+func (df *DataFrame) Duplicated(subset []string) ([]bool, error) {
+	codes, size, err := df.subsetCodes(subset)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make([]bool, size)
+	mask := make([]bool, len(codes))
+	for i, code := range codes {
+		mask[i] = seen[code]
+		seen[code] = true
+	}
+	return mask, nil
+}
+
+// DropDuplicates returns a new DataFrame without duplicate rows, comparing the subset columns, or
+// all columns when subset is empty, as Duplicated does. Kept rows stay in their original order.
+func (df *DataFrame) DropDuplicates(subset []string, keep Keep) (*DataFrame, error) {
+	codes, size, err := df.subsetCodes(subset)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []int
+	switch keep {
+	case KeepFirst:
+		rows = firstOccurrences(codes, size, len(codes))
+	case KeepLast:
+		last := make([]int, size)
+		for i, code := range codes {
+			last[code] = i
+		}
+		rows = make([]int, 0, size)
+		for i, code := range codes {
+			if last[code] == i {
+				rows = append(rows, i)
+			}
+		}
+	case KeepNone:
+		counts := make([]int, size)
+		for _, code := range codes {
+			counts[code]++
+		}
+		rows = make([]int, 0, len(codes))
+		for i, code := range codes {
+			if counts[code] == 1 {
+				rows = append(rows, i)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown keep policy %d", keep)
+	}
+
+	return df.take(rows), nil
+}
+
+// subsetCodes returns a code per row identifying its values in the subset columns, or in all
+// columns when subset is empty, together with the number of codes
+func (df *DataFrame) subsetCodes(subset []string) ([]int, int, error) {
+	columns := df.columns
+	if len(subset) > 0 {
+		var err error
+		if columns, err = joinKeys(df, subset); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	codes, size := rowCodes(columns)
+	if codes == nil {
+		codes = make([]int, df.RowCount())
+		size = min(df.RowCount(), 1)
+	}
+	return codes, size, nil
+}
@@ -0,0 +1,269 @@
+package dataframe
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SimilarityMetric selects how FuzzyJoin scores a pair of strings; every metric scores in [0, 1],
+// where 1 means the strings are equal
+type SimilarityMetric int
+
+const (
+	// Levenshtein scores one minus the edit distance divided by the length of the longer string
+	Levenshtein SimilarityMetric = iota
+	// JaroWinkler scores the Jaro similarity boosted by the length of the common prefix
+	JaroWinkler
+	// Trigram scores the share of three-character substrings the strings have in common
+	Trigram
+)
+
+// FuzzyJoinOptions configures FuzzyJoin. LeftOn and RightOn name the string columns to compare,
+// and pairs scoring at least Threshold are kept. ScoreColumn names the column holding the score,
+// defaulting to "score". BlockOn names columns present on both sides whose values must match
+// exactly, so that only rows within the same block are compared. Suffixes are applied as in Merge.
+type FuzzyJoinOptions struct {
+	LeftOn      string
+	RightOn     string
+	Metric      SimilarityMetric
+	Threshold   float64
+	ScoreColumn string
+	BlockOn     []string
+	Suffixes    [2]string
+}
+
+// FuzzyJoin pairs left and right rows whose string keys are similar under the chosen metric. The
+// result holds the left columns, the right columns other than blocking keys, and the score, ordered
+// by left row and then right row. Missing strings never match.
+func FuzzyJoin(left, right *DataFrame, opts FuzzyJoinOptions) (*DataFrame, error) {
+	if !(opts.Threshold >= 0 && opts.Threshold <= 1) {
+		return nil, fmt.Errorf("threshold %v is outside [0, 1]", opts.Threshold)
+	}
+	if opts.LeftOn == "" || opts.RightOn == "" {
+		return nil, errors.New("join columns are required")
+	}
+	leftKey, err := stringKey(left, opts.LeftOn)
+	if err != nil {
+		return nil, err
+	}
+	rightKey, err := stringKey(right, opts.RightOn)
+	if err != nil {
+		return nil, err
+	}
+
+	score, err := similarity(opts.Metric)
+	if err != nil {
+		return nil, err
+	}
+
+	leftCodes := make([]int, left.RowCount())
+	rightCodes := make([]int, right.RowCount())
+	size := 1
+	if len(opts.BlockOn) > 0 {
+		leftBlocks, err := joinKeys(left, opts.BlockOn)
+		if err != nil {
+			return nil, err
+		}
+		rightBlocks, err := joinKeys(right, opts.BlockOn)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkJoinable(leftBlocks, rightBlocks); err != nil {
+			return nil, err
+		}
+		rightCodes, leftCodes, size = buildProbeCodes(rightBlocks, leftBlocks, false)
+	}
+
+	blocks := make([][]int, size)
+	for j, code := range rightCodes {
+		if code >= 0 && rightKey.valid.get(j) {
+			blocks[code] = append(blocks[code], j)
+		}
+	}
+
+	prepared := make([]preparedString, len(rightKey.data))
+	for j := range prepared {
+		if rightKey.valid.get(j) {
+			prepared[j] = prepareString(rightKey.data[j], opts.Metric)
+		}
+	}
+
+	var leftRows, rightRows []int
+	var scores []float64
+	for i, code := range leftCodes {
+		if code < 0 || !leftKey.valid.get(i) {
+			continue
+		}
+		a := prepareString(leftKey.data[i], opts.Metric)
+		for _, j := range blocks[code] {
+			if s := score(a, prepared[j]); s >= opts.Threshold {
+				leftRows = append(leftRows, i)
+				rightRows = append(rightRows, j)
+				scores = append(scores, s)
+			}
+		}
+	}
+
+	shared := make(map[string]bool, len(opts.BlockOn))
+	for _, columnName := range opts.BlockOn {
+		shared[columnName] = true
+	}
+	joined, err := assembleMerge(left, right, shared, leftRows, rightRows, opts.Suffixes)
+	if err != nil {
+		return nil, err
+	}
+
+	scoreColumn := opts.ScoreColumn
+	if scoreColumn == "" {
+		scoreColumn = "score"
+	}
+	if _, ok := joined.index[scoreColumn]; ok {
+		return nil, fmt.Errorf("column name '%s' already exists", scoreColumn)
+	}
+	joined.appendSeries(NewFloat64Series(scoreColumn, scores))
+	return joined, nil
+}
+
+// stringKey returns the storage of a String or Categorical column
+func stringKey(df *DataFrame, columnName string) (*vector[string], error) {
+	s, err := df.Column(columnName)
+	if err != nil {
+		return nil, err
+	}
+	if !isText(s.dtype) {
+		return nil, fmt.Errorf("column '%s' holds %s values, not strings", columnName, s.dtype)
+	}
+	return s.data.(*vector[string]), nil
+}
+
+// preparedString holds a string in the form a similarity metric reads it
+type preparedString struct {
+	runes    []rune
+	trigrams map[string]bool
+}
+
+// prepareString converts a string into the form the metric reads
+func prepareString(s string, metric SimilarityMetric) preparedString {
+	if metric == Trigram {
+		return preparedString{trigrams: trigrams(s)}
+	}
+	return preparedString{runes: []rune(s)}
+}
+
+// similarity returns the scoring function of a metric
+func similarity(metric SimilarityMetric) (func(a, b preparedString) float64, error) {
+	switch metric {
+	case Levenshtein:
+		return func(a, b preparedString) float64 { return levenshteinSimilarity(a.runes, b.runes) }, nil
+	case JaroWinkler:
+		return func(a, b preparedString) float64 { return jaroWinkler(a.runes, b.runes) }, nil
+	case Trigram:
+		return func(a, b preparedString) float64 { return trigramSimilarity(a.trigrams, b.trigrams) }, nil
+	}
+	return nil, fmt.Errorf("unknown similarity metric %d", metric)
+}
+
+// levenshteinSimilarity returns one minus the edit distance between a and b divided by the
+// length of the longer one
+func levenshteinSimilarity(a, b []rune) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return 1 - float64(previous[len(b)])/float64(max(len(a), len(b)))
+}
+
+// jaroWinkler returns the Jaro-Winkler similarity of a and b, boosting the Jaro similarity by up
+// to four characters of common prefix
+func jaroWinkler(a, b []rune) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	window := max(max(len(a), len(b))/2-1, 0)
+	aMatched := make([]bool, len(a))
+	bMatched := make([]bool, len(b))
+	matches := 0
+	for i := range a {
+		for j := max(0, i-window); j < min(len(b), i+window+1); j++ {
+			if !bMatched[j] && a[i] == b[j] {
+				aMatched[i], bMatched[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	j := 0
+	for i := range a {
+		if !aMatched[i] {
+			continue
+		}
+		for !bMatched[j] {
+			j++
+		}
+		if a[i] != b[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(a)) + m/float64(len(b)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(a), len(b)) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// trigrams returns the set of three-character substrings of a string padded with two spaces in
+// front and one behind, so that short strings and word starts are represented
+func trigrams(s string) map[string]bool {
+	padded := []rune("  " + s + " ")
+	set := make(map[string]bool, len(padded))
+	for i := 0; i+3 <= len(padded); i++ {
+		set[string(padded[i:i+3])] = true
+	}
+	return set
+}
+
+// trigramSimilarity returns the number of trigrams two sets share divided by the number in either
+func trigramSimilarity(a, b map[string]bool) float64 {
+	shared := 0
+	for trigram := range a {
+		if b[trigram] {
+			shared++
+		}
+	}
+	union := len(a) + len(b) - shared
+	if union == 0 {
+		return 1
+	}
+	return float64(shared) / float64(union)
+}
@@ -0,0 +1,473 @@
+package dataframe
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// AggFunc identifies an aggregation computed over the rows of each group
+type AggFunc int
+
+const (
+	// AggSum adds up the values of a numeric column, keeping Int64 columns as Int64 and returning
+	// 0 for a group without values
+	AggSum AggFunc = iota
+	// AggMean averages the values of a numeric column as Float64
+	AggMean
+	// AggMin picks the smallest value using the column's ordering, under which a NaN in a Float64
+	// group sorts below every number and so becomes the minimum
+	AggMin
+	// AggMax picks the largest value using the column's ordering, under which a NaN in a Float64
+	// group is skipped unless the group holds nothing else
+	AggMax
+	// AggCount counts the non-missing values as Int64
+	AggCount
+	// AggNUnique counts the distinct non-missing values as Int64
+	AggNUnique
+	// AggFirst picks the first non-missing value; with PropagateNulls it picks the first row's
+	// value even when that is missing
+	AggFirst
+	// AggLast picks the last non-missing value; with PropagateNulls it picks the last row's value
+	// even when that is missing
+	AggLast
+	// AggStd computes the sample standard deviation as Float64, missing for fewer than two values
+	AggStd
+	// AggVar computes the sample variance as Float64, missing for fewer than two values
+	AggVar
+	// AggMedian computes the median as Float64, interpolating between the two middle values
+	AggMedian
+	// AggQuantile computes the Agg.Quantile quantile as Float64, interpolating linearly between
+	// the two nearest values
+	AggQuantile
+)
+
+var aggFuncNames = [...]string{
+	AggSum:      "sum",
+	AggMean:     "mean",
+	AggMin:      "min",
+	AggMax:      "max",
+	AggCount:    "count",
+	AggNUnique:  "nunique",
+	AggFirst:    "first",
+	AggLast:     "last",
+	AggStd:      "std",
+	AggVar:      "var",
+	AggMedian:   "median",
+	AggQuantile: "quantile",
+}
+
+// String returns the name of the aggregation
+func (f AggFunc) String() string {
+	if f >= 0 && int(f) < len(aggFuncNames) {
+		return aggFuncNames[f]
+	}
+	return fmt.Sprintf("AggFunc(%d)", int(f))
+}
+
+// Agg describes one aggregation of a column. Quantile is the quantile in [0, 1] computed by
+// AggQuantile. Name is the output column name, which defaults to the column name followed by an
+// underscore and the aggregation name, such as "Salary_mean".
+type Agg struct {
+	Func     AggFunc
+	Quantile float64
+	Name     string
+}
+
+// outputName returns the name of the column holding the aggregation of the named column
+func (a Agg) outputName(column string) string {
+	switch {
+	case a.Name != "":
+		return a.Name
+	case a.Func == AggQuantile:
+		return fmt.Sprintf("%s_%s_%g", column, a.Func, a.Quantile)
+	}
+	return column + "_" + a.Func.String()
+}
+
+// GroupedDataFrame is a DataFrame split into groups of rows with equal key values. Groups are kept
+// in order of first appearance; missing key values form a group of their own.
+type GroupedDataFrame struct {
+	df     *DataFrame
+	keys   []*Series
+	groups [][]int
+}
+
+// GroupBy groups the DataFrame by one or more columns
+func (df *DataFrame) GroupBy(columns ...string) (*GroupedDataFrame, error) {
+	if len(columns) == 0 {
+		return nil, errors.New("column names are required")
+	}
+
+	keys := make([]*Series, len(columns))
+	seen := make(map[string]bool, len(columns))
+	for k, col := range columns {
+		if seen[col] {
+			return nil, fmt.Errorf("column '%s' appears more than once", col)
+		}
+		seen[col] = true
+
+		s, err := df.Column(col)
+		if err != nil {
+			return nil, err
+		}
+		keys[k] = s
+	}
+
+	codes, size := rowCodes(keys)
+	groups := make([][]int, size)
+	for i, code := range codes {
+		groups[code] = append(groups[code], i)
+	}
+
+	return &GroupedDataFrame{df: df, keys: keys, groups: groups}, nil
+}
+
+// NumGroups returns the number of groups
+func (g *GroupedDataFrame) NumGroups() int {
+	return len(g.groups)
+}
+
+// Agg returns a DataFrame with one row per group, holding the key columns followed by the requested
+// aggregations of each column. Columns are aggregated in DataFrame order, and each column's
+// aggregations in the order given. Missing values are skipped unless PropagateNulls is given, in
+// which case a group holding one yields a missing result.
+func (g *GroupedDataFrame) Agg(aggs map[string][]Agg, opts ...AggOption) (*DataFrame, error) {
+	for columnName := range aggs {
+		if _, ok := g.df.index[columnName]; !ok {
+			return nil, fmt.Errorf("column '%s' does not exist", columnName)
+		}
+	}
+	config := newAggConfig(opts)
+
+	columns := make([]*Series, 0, len(g.keys)+len(aggs))
+	seen := make(map[string]bool, cap(columns))
+	add := func(s *Series) error {
+		if seen[s.name] {
+			return fmt.Errorf("column name '%s' already exists", s.name)
+		}
+		seen[s.name] = true
+		columns = append(columns, s)
+		return nil
+	}
+
+	firstRows := make([]int, len(g.groups))
+	for k, rows := range g.groups {
+		firstRows[k] = rows[0]
+	}
+	for _, key := range g.keys {
+		if err := add(key.take(firstRows)); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, s := range g.df.columns {
+		for _, agg := range aggs[s.name] {
+			aggregated, err := g.aggregate(s, agg, config)
+			if err != nil {
+				return nil, err
+			}
+			aggregated.name = agg.outputName(s.name)
+			if err := add(aggregated); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return frameOf(columns), nil
+}
+
+// Apply calls fn with each group as a DataFrame, in group order, and stacks the results, which must
+// all hold the same columns. Key columns missing from a result are added in front of it, repeating the
+// group's key values. A nil result drops the group.
+func (g *GroupedDataFrame) Apply(fn func(group *DataFrame) (*DataFrame, error)) (*DataFrame, error) {
+	results := make([]*DataFrame, 0, len(g.groups))
+	for k, rows := range g.groups {
+		result, err := fn(g.df.take(rows))
+		if err != nil {
+			return nil, fmt.Errorf("group %d: %w", k, err)
+		}
+		if result == nil {
+			continue
+		}
+
+		columns := make([]*Series, 0, len(g.keys)+len(result.columns))
+		for _, key := range g.keys {
+			if _, ok := result.index[key.name]; !ok {
+				columns = append(columns, key.take(repeatIndex(rows[0], result.RowCount())))
+			}
+		}
+		results = append(results, frameOf(append(columns, result.columns...)))
+	}
+
+	if len(results) == 0 {
+		columns := make([]*Series, len(g.keys))
+		for k, key := range g.keys {
+			columns[k] = key.take(nil)
+		}
+		return frameOf(columns), nil
+	}
+	return stackFrames(results)
+}
+
+// Transform calls fn with each group as a DataFrame and places the rows of each result at the
+// positions of the group's rows in this DataFrame. A result must hold either one row per row of the
+// group or a single row, which is repeated across the group, and all results must hold the same columns.
+func (g *GroupedDataFrame) Transform(fn func(group *DataFrame) (*DataFrame, error)) (*DataFrame, error) {
+	results := make([]*DataFrame, len(g.groups))
+	positions := make([]int, g.df.RowCount())
+	offset := 0
+	for k, rows := range g.groups {
+		result, err := fn(g.df.take(rows))
+		if err != nil {
+			return nil, fmt.Errorf("group %d: %w", k, err)
+		}
+		if result == nil {
+			return nil, fmt.Errorf("group %d: no result", k)
+		}
+
+		switch result.RowCount() {
+		case len(rows):
+		case 1:
+			result = result.take(repeatIndex(0, len(rows)))
+		default:
+			return nil, fmt.Errorf("group %d: result has %d rows, expected %d or 1", k, result.RowCount(), len(rows))
+		}
+
+		for j, i := range rows {
+			positions[i] = offset + j
+		}
+		offset += len(rows)
+		results[k] = result
+	}
+
+	stacked, err := stackFrames(results)
+	if err != nil {
+		return nil, err
+	}
+	return stacked.take(positions), nil
+}
+
+// stackFrames joins DataFrames holding the same columns end to end, in the column order of the first
+func stackFrames(frames []*DataFrame) (*DataFrame, error) {
+	if len(frames) == 0 {
+		return frameOf(nil), nil
+	}
+
+	names := frames[0].ColumnNames()
+	for _, frame := range frames[1:] {
+		if len(frame.columns) != len(names) {
+			return nil, fmt.Errorf("results have different columns: %v and %v", names, frame.ColumnNames())
+		}
+		for _, name := range names {
+			if _, ok := frame.index[name]; !ok {
+				return nil, fmt.Errorf("results have different columns: %v and %v", names, frame.ColumnNames())
+			}
+		}
+	}
+
+	columns := make([]*Series, len(names))
+	parts := make([]*Series, len(frames))
+	for i, name := range names {
+		for f, frame := range frames {
+			parts[f] = frame.columns[frame.index[name]]
+		}
+		s, err := concatSeries(name, parts)
+		if err != nil {
+			return nil, err
+		}
+		columns[i] = s
+	}
+	return frameOf(columns), nil
+}
+
+// repeatIndex returns n copies of the row index i
+func repeatIndex(i, n int) []int {
+	indices := make([]int, n)
+	for j := range indices {
+		indices[j] = i
+	}
+	return indices
+}
+
+// aggregate computes one aggregation of a Series for every group
+func (g *GroupedDataFrame) aggregate(s *Series, agg Agg, config aggConfig) (*Series, error) {
+	switch agg.Func {
+	case AggCount:
+		counts := make([]int64, len(g.groups))
+		for k, rows := range g.groups {
+			for _, i := range rows {
+				if !s.IsNull(i) {
+					counts[k]++
+				}
+			}
+		}
+		return NewInt64Series("", counts), nil
+
+	case AggNUnique:
+		codes := newFactorizer().encode(s)
+		marks := make([]int, len(codes))
+		counts := make([]int64, len(g.groups))
+		for k, rows := range g.groups {
+			for _, i := range rows {
+				if !s.IsNull(i) && marks[codes[i]] != k+1 {
+					marks[codes[i]] = k + 1
+					counts[k]++
+				}
+			}
+		}
+		return NewInt64Series("", counts), nil
+
+	case AggFirst, AggLast, AggMin, AggMax:
+		return s.take(g.pick(s, agg.Func, config)), nil
+
+	case AggSum, AggMean, AggStd, AggVar, AggMedian, AggQuantile:
+		if agg.Func == AggQuantile && !(agg.Quantile >= 0 && agg.Quantile <= 1) {
+			return nil, fmt.Errorf("quantile %v is outside [0, 1]", agg.Quantile)
+		}
+		if agg.Func == AggSum && s.dtype == Int64 {
+			return g.sumInt64(s, config), nil
+		}
+		return g.reduce(s, agg, config)
+	}
+
+	return nil, fmt.Errorf("unknown aggregation %s", agg.Func)
+}
+
+// pick returns, for every group, the row selected by a first, last, min or max aggregation, or -1
+// when the group has no such row
+func (g *GroupedDataFrame) pick(s *Series, f AggFunc, config aggConfig) []int {
+	compare := s.comparator()
+	picked := make([]int, len(g.groups))
+	for k, rows := range g.groups {
+		picked[k] = -1
+		switch f {
+		case AggFirst, AggLast:
+			if f == AggLast {
+				rows = slices.Clone(rows)
+				slices.Reverse(rows)
+			}
+			if config.propagateNulls {
+				picked[k] = rows[0]
+				continue
+			}
+			for _, i := range rows {
+				if !s.IsNull(i) {
+					picked[k] = i
+					break
+				}
+			}
+		default:
+			for _, i := range rows {
+				if s.IsNull(i) {
+					if config.propagateNulls {
+						picked[k] = -1
+						break
+					}
+					continue
+				}
+				if picked[k] < 0 {
+					picked[k] = i
+					continue
+				}
+				result := compare(i, picked[k])
+				if (f == AggMin && result < 0) || (f == AggMax && result > 0) {
+					picked[k] = i
+				}
+			}
+		}
+	}
+	return picked
+}
+
+// sumInt64 sums an Int64 Series within every group, keeping the integer type
+func (g *GroupedDataFrame) sumInt64(s *Series, config aggConfig) *Series {
+	v := s.data.(*vector[int64])
+	out := makeVector(make([]int64, len(g.groups)), newBitmap(len(g.groups), true))
+	for k, rows := range g.groups {
+		for _, i := range rows {
+			if !v.valid.get(i) {
+				if config.propagateNulls {
+					out.valid.set(k, false)
+					break
+				}
+				continue
+			}
+			out.data[k] += v.data[i]
+		}
+	}
+	return &Series{dtype: Int64, data: out}
+}
+
+// reduce computes a floating-point aggregation of a numeric Series within every group; groups with
+// too few values for the aggregation yield a missing value
+func (g *GroupedDataFrame) reduce(s *Series, agg Agg, config aggConfig) (*Series, error) {
+	values, valid, ok := s.float64s()
+	if !ok {
+		return nil, fmt.Errorf("column '%s' is not numeric", s.name)
+	}
+
+	out := makeVector(make([]float64, len(g.groups)), newBitmap(len(g.groups), false))
+	present := make([]float64, 0)
+	for k, rows := range g.groups {
+		present = present[:0]
+		hasNull := false
+		for _, i := range rows {
+			if valid.get(i) {
+				present = append(present, values[i])
+			} else {
+				hasNull = true
+			}
+		}
+		if hasNull && config.propagateNulls {
+			continue
+		}
+
+		var result float64
+		switch agg.Func {
+		case AggSum:
+			for _, value := range present {
+				result += value
+			}
+		case AggMean:
+			if len(present) == 0 {
+				continue
+			}
+			result = mean(present)
+		case AggVar, AggStd:
+			if len(present) <= 1 {
+				continue
+			}
+			result = variance(present)
+			if agg.Func == AggStd {
+				result = math.Sqrt(result)
+			}
+		case AggMedian, AggQuantile:
+			if len(present) == 0 {
+				continue
+			}
+			q := agg.Quantile
+			if agg.Func == AggMedian {
+				q = 0.5
+			}
+			slices.Sort(present)
+			result = quantile(present, q)
+		}
+		out.data[k] = result
+		out.valid.set(k, true)
+	}
+	return &Series{dtype: Float64, data: out}, nil
+}
+
+// quantile returns the q-th quantile of a sorted, non-empty slice, interpolating linearly between
+// the two nearest values
+func quantile(sorted []float64, q float64) float64 {
+	position := q * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	if lower >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	fraction := position - float64(lower)
+	return sorted[lower] + fraction*(sorted[lower+1]-sorted[lower])
+}
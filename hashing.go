@@ -0,0 +1,161 @@
+package dataframe
+
+import (
+	"math"
+	"time"
+)
+
+// factorizer assigns dense integer codes to values in order of first appearance, so that equal
+// values in any of the Series it encodes receive equal codes. Integers and floats are keyed
+// separately unless encoded as floats; all NaNs share one code, as do all missing values. Once
+// frozen, it only looks values up, giving -1 to values it has not seen.
+type factorizer struct {
+	ints     map[int64]int
+	floats   map[uint64]int
+	strings  map[string]int
+	times    map[timeKey]int
+	bools    [2]int
+	nullCode int
+	size     int
+	frozen   bool
+}
+
+// timeKey identifies an instant independently of its location
+type timeKey struct {
+	sec  int64
+	nsec int32
+}
+
+// newFactorizer returns an empty factorizer
+func newFactorizer() *factorizer {
+	return &factorizer{
+		ints:     make(map[int64]int),
+		floats:   make(map[uint64]int),
+		strings:  make(map[string]int),
+		times:    make(map[timeKey]int),
+		bools:    [2]int{-1, -1},
+		nullCode: -1,
+	}
+}
+
+// encode returns a code for every row of the Series
+func (f *factorizer) encode(s *Series) []int {
+	codes := make([]int, s.Len())
+	switch c := s.data.(type) {
+	case *vector[int64]:
+		encodeVector(f, f.ints, c, func(x int64) int64 { return x }, codes)
+	case *vector[float64]:
+		encodeVector(f, f.floats, c, floatKey, codes)
+	case *vector[string]:
+		encodeVector(f, f.strings, c, func(x string) string { return x }, codes)
+	case *vector[time.Time]:
+		encodeVector(f, f.times, c, func(x time.Time) timeKey { return timeKey{x.Unix(), int32(x.Nanosecond())} }, codes)
+	case *vector[bool]:
+		for i, x := range c.data {
+			switch {
+			case !c.valid.get(i):
+				codes[i] = f.code(&f.nullCode)
+			case x:
+				codes[i] = f.code(&f.bools[1])
+			default:
+				codes[i] = f.code(&f.bools[0])
+			}
+		}
+	default:
+		for i := range codes {
+			codes[i] = f.code(&f.nullCode)
+		}
+	}
+	return codes
+}
+
+// encodeFloats returns a code for every row of a numeric Series, keying integers as floats so
+// that they match equal float values
+func (f *factorizer) encodeFloats(s *Series) []int {
+	values, valid, ok := s.float64s()
+	if !ok {
+		return f.encode(s)
+	}
+	codes := make([]int, len(values))
+	encodeVector(f, f.floats, &vector[float64]{data: values, valid: valid}, floatKey, codes)
+	return codes
+}
+
+// code returns the code stored in slot, assigning the next free code on first use
+func (f *factorizer) code(slot *int) int {
+	if *slot < 0 && !f.frozen {
+		*slot = f.size
+		f.size++
+	}
+	return *slot
+}
+
+// encodeVector fills codes with the code of every row of a vector, keyed through key
+func encodeVector[T any, K comparable](f *factorizer, dictionary map[K]int, v *vector[T], key func(T) K, codes []int) {
+	for i, x := range v.data {
+		if !v.valid.get(i) {
+			codes[i] = f.code(&f.nullCode)
+			continue
+		}
+		k := key(x)
+		code, ok := dictionary[k]
+		if !ok && f.frozen {
+			code = -1
+		} else if !ok {
+			code = f.size
+			f.size++
+			dictionary[k] = code
+		}
+		codes[i] = code
+	}
+}
+
+// floatKey maps a float to a map key under which all NaNs are equal and -0 equals +0
+func floatKey(x float64) uint64 {
+	switch {
+	case math.IsNaN(x):
+		return math.Float64bits(math.NaN())
+	case x == 0:
+		return 0
+	}
+	return math.Float64bits(x)
+}
+
+// combineCodes merges per-column codes into a single code per row identifying the whole key,
+// numbered in order of first appearance, and returns the number of distinct keys
+func combineCodes(columns [][]int) ([]int, int) {
+	if len(columns) == 0 {
+		return nil, 0
+	}
+
+	codes := make([]int, len(columns[0]))
+	copy(codes, columns[0])
+	size := 0
+	for _, code := range codes {
+		size = max(size, code+1)
+	}
+
+	for _, next := range columns[1:] {
+		pairs := make(map[[2]int]int)
+		for i, code := range codes {
+			pair := [2]int{code, next[i]}
+			combined, ok := pairs[pair]
+			if !ok {
+				combined = len(pairs)
+				pairs[pair] = combined
+			}
+			codes[i] = combined
+		}
+		size = len(pairs)
+	}
+	return codes, size
+}
+
+// rowCodes returns a code per row identifying the combined values of the given Series
+func rowCodes(series []*Series) ([]int, int) {
+	columns := make([][]int, len(series))
+	for i, s := range series {
+		columns[i] = newFactorizer().encode(s)
+	}
+	return combineCodes(columns)
+}
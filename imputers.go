@@ -0,0 +1,357 @@
+package dataframe
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Imputer learns how to fill missing values from one DataFrame and fills them in any DataFrame
+// holding the same columns
+type Imputer interface {
+	Fit(df *DataFrame) error
+	Transform(df *DataFrame) (*DataFrame, error)
+}
+
+// FitTransform fits an Imputer to a DataFrame and fills the missing values of that DataFrame
+func FitTransform(imputer Imputer, df *DataFrame) (*DataFrame, error) {
+	if err := imputer.Fit(df); err != nil {
+		return nil, err
+	}
+	return imputer.Transform(df)
+}
+
+// KNNImputer fills a missing value with the mean of that column over the K nearest rows of the
+// fitted DataFrame that hold it. Distances are Euclidean over the numeric columns both rows hold,
+// scaled up by the share of columns missing from either row.
+type KNNImputer struct {
+	columns   []string
+	k         int
+	reference [][]float64
+	present   [][]bool
+}
+
+// NewKNNImputer returns a KNNImputer over the given numeric columns using k neighbours
+func NewKNNImputer(columns []string, k int) *KNNImputer {
+	return &KNNImputer{columns: columns, k: k}
+}
+
+// Fit stores the rows of a DataFrame to search for neighbours
+func (imp *KNNImputer) Fit(df *DataFrame) error {
+	if imp.k < 1 {
+		return fmt.Errorf("number of neighbours must be positive, not %d", imp.k)
+	}
+	values, present, err := df.numericMatrix(imp.columns)
+	if err != nil {
+		return err
+	}
+	imp.reference, imp.present = values, present
+	return nil
+}
+
+// Transform returns a new DataFrame with the missing values of the imputer's columns filled from
+// their nearest neighbours; the columns become Float64 columns. Values without any neighbour
+// holding them stay missing.
+func (imp *KNNImputer) Transform(df *DataFrame) (*DataFrame, error) {
+	if imp.reference == nil {
+		return nil, errors.New("imputer is not fitted")
+	}
+	values, present, err := df.numericMatrix(imp.columns)
+	if err != nil {
+		return nil, err
+	}
+
+	type neighbour struct {
+		row      int
+		distance float64
+	}
+	filled := make([][]bool, len(values))
+	for i, row := range values {
+		filled[i] = slices.Clone(present[i])
+		if !slices.Contains(present[i], false) {
+			continue
+		}
+
+		neighbours := make([]neighbour, 0, len(imp.reference))
+		for r, candidate := range imp.reference {
+			if d, ok := nanEuclidean(row, present[i], candidate, imp.present[r]); ok {
+				neighbours = append(neighbours, neighbour{r, d})
+			}
+		}
+		slices.SortStableFunc(neighbours, func(a, b neighbour) int {
+			return cmp.Compare(a.distance, b.distance)
+		})
+
+		for c := range row {
+			if present[i][c] {
+				continue
+			}
+			sum, count := 0.0, 0
+			for _, n := range neighbours {
+				if count == imp.k {
+					break
+				}
+				if imp.present[n.row][c] {
+					sum += imp.reference[n.row][c]
+					count++
+				}
+			}
+			if count > 0 {
+				row[c] = sum / float64(count)
+				filled[i][c] = true
+			}
+		}
+	}
+
+	return df.withNumericMatrix(imp.columns, values, filled), nil
+}
+
+// nanEuclidean returns the Euclidean distance between two rows over the columns both hold, scaled
+// by the square root of the share of columns they share, reporting false when they share none
+func nanEuclidean(a []float64, aPresent []bool, b []float64, bPresent []bool) (float64, bool) {
+	sum, shared := 0.0, 0
+	for c := range a {
+		if aPresent[c] && bPresent[c] {
+			sum += (a[c] - b[c]) * (a[c] - b[c])
+			shared++
+		}
+	}
+	if shared == 0 {
+		return 0, false
+	}
+	return math.Sqrt(sum * float64(len(a)) / float64(shared)), true
+}
+
+// IterativeImputer fills missing values by chained equations: starting from column means, it
+// repeatedly regresses each column on the others by least squares and replaces the column's
+// missing values with the predictions, for a set number of rounds. Fitting learns the means and
+// the final regression of every column, which Transform replays on new data.
+type IterativeImputer struct {
+	columns      []string
+	rounds       int
+	means        []float64
+	coefficients [][]float64
+}
+
+// NewIterativeImputer returns an IterativeImputer over the given numeric columns running the given
+// number of rounds
+func NewIterativeImputer(columns []string, rounds int) *IterativeImputer {
+	return &IterativeImputer{columns: columns, rounds: rounds}
+}
+
+// Fit learns the column means and regressions from a DataFrame
+func (imp *IterativeImputer) Fit(df *DataFrame) error {
+	if imp.rounds < 1 {
+		return fmt.Errorf("number of rounds must be positive, not %d", imp.rounds)
+	}
+	values, present, err := df.numericMatrix(imp.columns)
+	if err != nil {
+		return err
+	}
+
+	means := make([]float64, len(imp.columns))
+	for c, columnName := range imp.columns {
+		column := make([]float64, 0, len(values))
+		for i := range values {
+			if present[i][c] {
+				column = append(column, values[i][c])
+			}
+		}
+		if len(column) == 0 {
+			return fmt.Errorf("column '%s' has no values to fit", columnName)
+		}
+		means[c] = mean(column)
+	}
+	fillMeans(values, present, means)
+
+	coefficients := make([][]float64, len(imp.columns))
+	for round := 0; round < imp.rounds; round++ {
+		for c := range imp.columns {
+			coefficients[c] = regressColumn(values, present, c)
+			predictMissing(values, present, c, coefficients[c])
+		}
+	}
+
+	imp.means, imp.coefficients = means, coefficients
+	return nil
+}
+
+// Transform returns a new DataFrame with the missing values of the imputer's columns filled by
+// replaying the fitted regressions; the columns become Float64 columns
+func (imp *IterativeImputer) Transform(df *DataFrame) (*DataFrame, error) {
+	if imp.coefficients == nil {
+		return nil, errors.New("imputer is not fitted")
+	}
+	values, present, err := df.numericMatrix(imp.columns)
+	if err != nil {
+		return nil, err
+	}
+
+	fillMeans(values, present, imp.means)
+	for round := 0; round < imp.rounds; round++ {
+		for c := range imp.columns {
+			predictMissing(values, present, c, imp.coefficients[c])
+		}
+	}
+
+	filled := make([][]bool, len(values))
+	for i := range filled {
+		filled[i] = make([]bool, len(imp.columns))
+		for c := range filled[i] {
+			filled[i][c] = true
+		}
+	}
+	return df.withNumericMatrix(imp.columns, values, filled), nil
+}
+
+// fillMeans replaces every missing value with the mean of its column
+func fillMeans(values [][]float64, present [][]bool, means []float64) {
+	for i := range values {
+		for c := range values[i] {
+			if !present[i][c] {
+				values[i][c] = means[c]
+			}
+		}
+	}
+}
+
+// regressColumn fits column c on the other columns over the rows where c is present, returning
+// the intercept followed by one coefficient per column, with a zero for c itself. A small ridge
+// penalty keeps the fit defined when columns are collinear.
+func regressColumn(values [][]float64, present [][]bool, c int) []float64 {
+	p := len(values[0]) + 1
+	gram := make([][]float64, p)
+	for a := range gram {
+		gram[a] = make([]float64, p)
+	}
+	moments := make([]float64, p)
+
+	features := make([]float64, p)
+	for i, row := range values {
+		if !present[i][c] {
+			continue
+		}
+		features[0] = 1
+		for k, value := range row {
+			features[k+1] = value
+		}
+		features[c+1] = 0
+
+		for a := range features {
+			moments[a] += features[a] * row[c]
+			for b := range features {
+				gram[a][b] += features[a] * features[b]
+			}
+		}
+	}
+	for a := 1; a < p; a++ {
+		gram[a][a] += 1e-6
+	}
+	gram[c+1][c+1] = 1
+
+	return solveLinear(gram, moments)
+}
+
+// predictMissing replaces the missing values of column c with the predictions of its regression
+func predictMissing(values [][]float64, present [][]bool, c int, coefficients []float64) {
+	for i, row := range values {
+		if present[i][c] {
+			continue
+		}
+		prediction := coefficients[0]
+		for k, value := range row {
+			if k != c {
+				prediction += coefficients[k+1] * value
+			}
+		}
+		row[c] = prediction
+	}
+}
+
+// solveLinear solves the square system a·x = b by Gaussian elimination with partial pivoting,
+// overwriting a and b; singular directions get a zero coefficient
+func solveLinear(a [][]float64, b []float64) []float64 {
+	n := len(b)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+		if a[col][col] == 0 {
+			continue
+		}
+
+		for row := col + 1; row < n; row++ {
+			factor := a[row][col] / a[col][col]
+			for k := col; k < n; k++ {
+				a[row][k] -= factor * a[col][k]
+			}
+			b[row] -= factor * b[col]
+		}
+	}
+
+	x := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		if a[row][row] == 0 {
+			continue
+		}
+		sum := b[row]
+		for k := row + 1; k < n; k++ {
+			sum -= a[row][k] * x[k]
+		}
+		x[row] = sum / a[row][row]
+	}
+	return x
+}
+
+// numericMatrix returns the values of numeric columns row by row, with a matching mask marking the
+// values that are present
+func (df *DataFrame) numericMatrix(columns []string) ([][]float64, [][]bool, error) {
+	if len(columns) == 0 {
+		return nil, nil, errors.New("column names are required")
+	}
+	series, err := joinKeys(df, columns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := make([][]float64, df.RowCount())
+	present := make([][]bool, df.RowCount())
+	for i := range values {
+		values[i] = make([]float64, len(series))
+		present[i] = make([]bool, len(series))
+	}
+	for c, s := range series {
+		column, valid, ok := s.float64s()
+		if !ok {
+			return nil, nil, fmt.Errorf("column '%s' is not numeric", s.name)
+		}
+		for i, value := range column {
+			values[i][c], present[i][c] = value, valid.get(i)
+		}
+	}
+	return values, present, nil
+}
+
+// withNumericMatrix returns a new DataFrame with the given columns replaced by Float64 columns
+// built from a row-by-row matrix and a mask marking the values that are present
+func (df *DataFrame) withNumericMatrix(columns []string, values [][]float64, present [][]bool) *DataFrame {
+	result := df
+	for c, columnName := range columns {
+		out := makeVector(make([]float64, len(values)), newBitmap(len(values), false))
+		for i := range values {
+			if present[i][c] {
+				out.data[i] = values[i][c]
+				out.valid.set(i, true)
+			}
+		}
+		result = result.withColumn(df.index[columnName], &Series{name: columnName, dtype: Float64, data: out})
+	}
+	return result
+}
@@ -0,0 +1,356 @@
+package dataframe
+
+import (
+	"cmp"
+	"container/heap"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// JoinHow selects which rows Merge keeps
+type JoinHow int
+
+const (
+	// InnerJoin keeps the pairs of rows whose keys match
+	InnerJoin JoinHow = iota
+	// LeftJoin keeps every left row, paired with its matches or with missing values
+	LeftJoin
+	// RightJoin keeps every right row, paired with its matches or with missing values
+	RightJoin
+	// OuterJoin keeps every row of both sides, paired with its matches or with missing values
+	OuterJoin
+	// CrossJoin pairs every left row with every right row
+	CrossJoin
+	// SemiJoin keeps the left rows that have a match, with the left columns only
+	SemiJoin
+	// AntiJoin keeps the left rows that have no match, with the left columns only
+	AntiJoin
+)
+
+// MergeOptions configures Merge. On names key columns present in both DataFrames; LeftOn and
+// RightOn name them separately for each side, pairing them in order. Missing keys never match
+// unless NullsEqual is set. Suffixes are appended to the names of columns present on both sides
+// other than shared keys, and default to "_x" and "_y". Strategy selects the join algorithm, and
+// MaxBuildRows, when positive, bounds the rows a hash join loads into one hash table by splitting
+// both sides into partitions by key.
+type MergeOptions struct {
+	On           []string
+	LeftOn       []string
+	RightOn      []string
+	How          JoinHow
+	Suffixes     [2]string
+	NullsEqual   bool
+	Strategy     JoinStrategy
+	MaxBuildRows int
+}
+
+// Merge joins two DataFrames on key columns. The result holds the left columns followed by the
+// right columns; a key named the same on both sides appears once, in its left position, filled
+// from whichever side has a value. Rows follow the order of the left DataFrame, except for right
+// joins, which follow the right, and unmatched right rows of outer joins, which come last.
+func Merge(left, right *DataFrame, opts MergeOptions) (*DataFrame, error) {
+	if opts.How == CrossJoin {
+		if len(opts.On) > 0 || len(opts.LeftOn) > 0 || len(opts.RightOn) > 0 {
+			return nil, errors.New("cross join takes no join columns")
+		}
+		leftRows, rightRows := crossRows(left.RowCount(), right.RowCount())
+		return assembleMerge(left, right, nil, leftRows, rightRows, opts.Suffixes)
+	}
+
+	leftOn, rightOn, err := mergeColumns(opts)
+	if err != nil {
+		return nil, err
+	}
+	leftKeys, err := joinKeys(left, leftOn)
+	if err != nil {
+		return nil, err
+	}
+	rightKeys, err := joinKeys(right, rightOn)
+	if err != nil {
+		return nil, err
+	}
+
+	var leftRows, rightRows []int
+	switch opts.How {
+	case InnerJoin, LeftJoin, RightJoin, OuterJoin:
+		leftRows, rightRows, err = matchRows(leftKeys, rightKeys, opts)
+		if err != nil {
+			return nil, err
+		}
+		switch opts.How {
+		case LeftJoin:
+			leftRows, rightRows = withUnmatched(leftRows, rightRows, left.RowCount())
+		case RightJoin:
+			rightRows, leftRows = sortPairs(rightRows, leftRows, right.RowCount())
+			rightRows, leftRows = withUnmatched(rightRows, leftRows, right.RowCount())
+		case OuterJoin:
+			matched := make([]bool, right.RowCount())
+			for _, j := range rightRows {
+				matched[j] = true
+			}
+			leftRows, rightRows = withUnmatched(leftRows, rightRows, left.RowCount())
+			for j, ok := range matched {
+				if !ok {
+					leftRows = append(leftRows, -1)
+					rightRows = append(rightRows, j)
+				}
+			}
+		}
+	case SemiJoin, AntiJoin:
+		matched, err := matchedRows(leftKeys, rightKeys, opts)
+		if err != nil {
+			return nil, err
+		}
+		rows := make([]int, 0, len(matched))
+		for i, ok := range matched {
+			if ok == (opts.How == SemiJoin) {
+				rows = append(rows, i)
+			}
+		}
+		return left.take(rows), nil
+	default:
+		return nil, fmt.Errorf("unknown join type %d", opts.How)
+	}
+
+	shared := make(map[string]bool, len(leftOn))
+	for k := range leftOn {
+		if leftOn[k] == rightOn[k] {
+			shared[leftOn[k]] = true
+		}
+	}
+	return assembleMerge(left, right, shared, leftRows, rightRows, opts.Suffixes)
+}
+
+// mergeColumns returns the left and right key column names given by the options
+func mergeColumns(opts MergeOptions) ([]string, []string, error) {
+	switch {
+	case len(opts.On) > 0 && (len(opts.LeftOn) > 0 || len(opts.RightOn) > 0):
+		return nil, nil, errors.New("join columns must be given either with On or with LeftOn and RightOn")
+	case len(opts.On) > 0:
+		return opts.On, opts.On, nil
+	case len(opts.LeftOn) == 0 && len(opts.RightOn) == 0:
+		return nil, nil, errors.New("join columns are required")
+	case len(opts.LeftOn) != len(opts.RightOn):
+		return nil, nil, fmt.Errorf("%d left join columns do not pair with %d right join columns", len(opts.LeftOn), len(opts.RightOn))
+	}
+	return opts.LeftOn, opts.RightOn, nil
+}
+
+// joinKeys returns the named key columns of a DataFrame
+func joinKeys(df *DataFrame, columns []string) ([]*Series, error) {
+	keys := make([]*Series, len(columns))
+	seen := make(map[string]bool, len(columns))
+	for k, columnName := range columns {
+		if seen[columnName] {
+			return nil, fmt.Errorf("column '%s' appears more than once", columnName)
+		}
+		seen[columnName] = true
+
+		s, err := df.Column(columnName)
+		if err != nil {
+			return nil, err
+		}
+		keys[k] = s
+	}
+	return keys, nil
+}
+
+// crossRows returns the row pairs of a cross join, in left order and then right order
+func crossRows(leftCount, rightCount int) ([]int, []int) {
+	leftRows := make([]int, 0, leftCount*rightCount)
+	rightRows := make([]int, 0, leftCount*rightCount)
+	for i := 0; i < leftCount; i++ {
+		for j := 0; j < rightCount; j++ {
+			leftRows = append(leftRows, i)
+			rightRows = append(rightRows, j)
+		}
+	}
+	return leftRows, rightRows
+}
+
+// assembleMerge builds the result of a join from pairs of left and right row indices, where -1
+// stands for a missing row. Shared key columns are combined; other columns present on both
+// sides are renamed with the suffixes.
+func assembleMerge(left, right *DataFrame, shared map[string]bool, leftRows, rightRows []int, suffixes [2]string) (*DataFrame, error) {
+	if suffixes == [2]string{} {
+		suffixes = [2]string{"_x", "_y"}
+	}
+
+	columns := make([]*Series, 0, len(left.columns)+len(right.columns))
+	seen := make(map[string]bool, cap(columns))
+	add := func(s *Series) error {
+		if seen[s.name] {
+			return fmt.Errorf("column name '%s' already exists", s.name)
+		}
+		seen[s.name] = true
+		columns = append(columns, s)
+		return nil
+	}
+
+	for _, s := range left.columns {
+		taken := s.take(leftRows)
+		if shared[s.name] {
+			taken = coalesce(taken, right.columns[right.index[s.name]].take(rightRows))
+		} else if _, ok := right.index[s.name]; ok {
+			taken.name += suffixes[0]
+		}
+		if err := add(taken); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, s := range right.columns {
+		if shared[s.name] {
+			continue
+		}
+		taken := s.take(rightRows)
+		if _, ok := left.index[s.name]; ok {
+			taken.name += suffixes[1]
+		}
+		if err := add(taken); err != nil {
+			return nil, err
+		}
+	}
+
+	return frameOf(columns), nil
+}
+
+// coalesce returns a copy of a with its missing values filled from b, which has the same length;
+// numeric Series of different types are combined as Float64
+func coalesce(a, b *Series) *Series {
+	switch {
+	case b.dtype == Null:
+		return a
+	case a.dtype == Null:
+		return b.rename(a.name)
+	case a.dtype != b.dtype && a.dtype.IsNumeric() && b.dtype.IsNumeric():
+		a, b = a.asFloat64(), b.asFloat64()
+	}
+
+	data := a.data.Clone()
+	for i := 0; i < a.Len(); i++ {
+		if a.IsNull(i) && !b.IsNull(i) {
+			data.set(i, b.Value(i))
+		}
+	}
+	return &Series{name: a.name, dtype: a.dtype, data: data}
+}
+
+// IntervalClosed selects which ends of an interval IntervalJoin treats as inside it
+type IntervalClosed int
+
+const (
+	// ClosedBoth includes both the start and the end of the interval
+	ClosedBoth IntervalClosed = iota
+	// ClosedLeft includes the start but not the end
+	ClosedLeft
+	// ClosedRight includes the end but not the start
+	ClosedRight
+	// ClosedNeither excludes both the start and the end
+	ClosedNeither
+)
+
+// IntervalJoin pairs every left row with the right rows whose interval, running from the
+// rightStart column to the rightEnd column, contains the value of the left column. The columns
+// must all be numeric or all Datetime; rows with missing values or NaN never match. Rows are
+// matched by sorting both sides and sweeping them in step, and the result holds the left columns
+// followed by the right columns, ordered by left row and then right row.
+func IntervalJoin(left, right *DataFrame, leftCol, rightStart, rightEnd string, closed IntervalClosed) (*DataFrame, error) {
+	if closed < ClosedBoth || closed > ClosedNeither {
+		return nil, fmt.Errorf("unknown interval closure %d", closed)
+	}
+	values, err := left.Column(leftCol)
+	if err != nil {
+		return nil, err
+	}
+	starts, err := right.Column(rightStart)
+	if err != nil {
+		return nil, err
+	}
+	ends, err := right.Column(rightEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	var leftRows, rightRows []int
+	switch {
+	case values.dtype == Datetime && starts.dtype == Datetime && ends.dtype == Datetime:
+		leftRows, rightRows = intervalMatch(unixNanos(values), unixNanos(starts), unixNanos(ends), closed)
+	case values.dtype == Int64 && starts.dtype == Int64 && ends.dtype == Int64:
+		leftRows, rightRows = intervalMatch(values.data.(*vector[int64]), starts.data.(*vector[int64]), ends.data.(*vector[int64]), closed)
+	case values.dtype.IsNumeric() && starts.dtype.IsNumeric() && ends.dtype.IsNumeric():
+		leftRows, rightRows = intervalMatch(values.asFloat64().data.(*vector[float64]), starts.asFloat64().data.(*vector[float64]), ends.asFloat64().data.(*vector[float64]), closed)
+	default:
+		return nil, fmt.Errorf("interval join columns '%s', '%s' and '%s' must all be numeric or all Datetime", leftCol, rightStart, rightEnd)
+	}
+
+	return assembleMerge(left, right, nil, leftRows, rightRows, [2]string{})
+}
+
+// intervalMatch returns the pairs of value rows and interval rows where the interval contains the
+// value, ordered by value row and then interval row. Values are visited in ascending order while
+// intervals open in order of their start and are kept in a heap by end until they close.
+func intervalMatch[K int64 | float64](values, starts, ends *vector[K], closed IntervalClosed) ([]int, []int) {
+	order := make([]int, 0, len(values.data))
+	for i, value := range values.data {
+		if values.valid.get(i) && value == value {
+			order = append(order, i)
+		}
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return cmp.Compare(values.data[a], values.data[b]) })
+
+	intervals := make([]int, 0, len(starts.data))
+	for j := range starts.data {
+		if starts.valid.get(j) && ends.valid.get(j) && starts.data[j] == starts.data[j] && ends.data[j] == ends.data[j] {
+			intervals = append(intervals, j)
+		}
+	}
+	slices.SortStableFunc(intervals, func(a, b int) int { return cmp.Compare(starts.data[a], starts.data[b]) })
+
+	includesStart := closed == ClosedBoth || closed == ClosedLeft
+	includesEnd := closed == ClosedBoth || closed == ClosedRight
+	active := &intervalHeap[K]{ends: ends.data}
+	var pairs [][2]int
+	next := 0
+	for _, i := range order {
+		value := values.data[i]
+		for next < len(intervals) && (starts.data[intervals[next]] < value || (includesStart && starts.data[intervals[next]] == value)) {
+			heap.Push(active, intervals[next])
+			next++
+		}
+		for active.Len() > 0 && (ends.data[active.rows[0]] < value || (!includesEnd && ends.data[active.rows[0]] == value)) {
+			heap.Pop(active)
+		}
+		for _, j := range active.rows {
+			pairs = append(pairs, [2]int{i, j})
+		}
+	}
+
+	slices.SortFunc(pairs, func(a, b [2]int) int {
+		return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(a[1], b[1]))
+	})
+	leftRows := make([]int, len(pairs))
+	rightRows := make([]int, len(pairs))
+	for k, pair := range pairs {
+		leftRows[k], rightRows[k] = pair[0], pair[1]
+	}
+	return leftRows, rightRows
+}
+
+// intervalHeap is a min-heap of interval rows ordered by their end
+type intervalHeap[K int64 | float64] struct {
+	rows []int
+	ends []K
+}
+
+func (h *intervalHeap[K]) Len() int           { return len(h.rows) }
+func (h *intervalHeap[K]) Less(a, b int) bool { return h.ends[h.rows[a]] < h.ends[h.rows[b]] }
+func (h *intervalHeap[K]) Swap(a, b int)      { h.rows[a], h.rows[b] = h.rows[b], h.rows[a] }
+func (h *intervalHeap[K]) Push(x any)         { h.rows = append(h.rows, x.(int)) }
+
+func (h *intervalHeap[K]) Pop() any {
+	last := h.rows[len(h.rows)-1]
+	h.rows = h.rows[:len(h.rows)-1]
+	return last
+}
@@ -0,0 +1,472 @@
+package dataframe
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"hash/maphash"
+	"slices"
+	"time"
+)
+
+// JoinStrategy selects the algorithm Merge uses to pair rows with equal keys
+type JoinStrategy int
+
+const (
+	// AutoStrategy uses a sort-merge join when both sides are already sorted on the keys and a
+	// hash join otherwise
+	AutoStrategy JoinStrategy = iota
+	// HashStrategy builds a hash table on the smaller side and probes it with the larger one
+	HashStrategy
+	// SortMergeStrategy walks both sides in step; they must be sorted in ascending order on the
+	// keys, with missing keys last
+	SortMergeStrategy
+)
+
+// matchRows returns the pairs of left and right rows with equal keys, ordered by left row and
+// then by right row
+func matchRows(leftKeys, rightKeys []*Series, opts MergeOptions) ([]int, []int, error) {
+	if err := checkJoinable(leftKeys, rightKeys); err != nil {
+		return nil, nil, err
+	}
+
+	switch opts.Strategy {
+	case AutoStrategy:
+		if keysSorted(leftKeys) && keysSorted(rightKeys) {
+			leftRows, rightRows := sortMergeMatch(leftKeys, rightKeys, opts.NullsEqual)
+			return leftRows, rightRows, nil
+		}
+		leftRows, rightRows := hashMatch(leftKeys, rightKeys, opts.NullsEqual, opts.MaxBuildRows)
+		return leftRows, rightRows, nil
+	case HashStrategy:
+		leftRows, rightRows := hashMatch(leftKeys, rightKeys, opts.NullsEqual, opts.MaxBuildRows)
+		return leftRows, rightRows, nil
+	case SortMergeStrategy:
+		if !keysSorted(leftKeys) || !keysSorted(rightKeys) {
+			return nil, nil, errors.New("sort-merge join requires both sides sorted on the join columns with missing values last")
+		}
+		leftRows, rightRows := sortMergeMatch(leftKeys, rightKeys, opts.NullsEqual)
+		return leftRows, rightRows, nil
+	}
+	return nil, nil, fmt.Errorf("unknown join strategy %d", opts.Strategy)
+}
+
+// matchedRows reports for every left row whether some right row has an equal key, pairing rows
+// with the strategy given by the options
+func matchedRows(leftKeys, rightKeys []*Series, opts MergeOptions) ([]bool, error) {
+	leftRows, _, err := matchRows(leftKeys, rightKeys, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]bool, leftKeys[0].Len())
+	for _, i := range leftRows {
+		matched[i] = true
+	}
+	return matched, nil
+}
+
+// checkJoinable fails when a pair of key columns holds values that can never be equal
+func checkJoinable(leftKeys, rightKeys []*Series) error {
+	for k := range leftKeys {
+		l, r := leftKeys[k], rightKeys[k]
+		switch {
+		case l.dtype == r.dtype, l.dtype == Null, r.dtype == Null:
+		case isText(l.dtype) && isText(r.dtype), l.dtype.IsNumeric() && r.dtype.IsNumeric():
+		default:
+			return fmt.Errorf("cannot join column '%s' of type %s with column '%s' of type %s", l.name, l.dtype, r.name, r.dtype)
+		}
+	}
+	return nil
+}
+
+// isText reports whether the type is stored as strings
+func isText(t DType) bool {
+	return t == String || t == Categorical
+}
+
+// hashMatch pairs rows with equal keys by building a hash table on the smaller side, splitting
+// both sides into partitions by key first when the smaller side exceeds maxBuildRows
+func hashMatch(leftKeys, rightKeys []*Series, nullsEqual bool, maxBuildRows int) ([]int, []int) {
+	leftCount, rightCount := leftKeys[0].Len(), rightKeys[0].Len()
+	if smaller := min(leftCount, rightCount); maxBuildRows > 0 && smaller > maxBuildRows {
+		return partitionedHashMatch(leftKeys, rightKeys, nullsEqual, (smaller+maxBuildRows-1)/maxBuildRows)
+	}
+
+	if leftCount < rightCount {
+		leftCodes, rightCodes, size := buildProbeCodes(leftKeys, rightKeys, nullsEqual)
+		rightRows, leftRows := probeRows(rightCodes, leftCodes, size)
+		return sortPairs(leftRows, rightRows, leftCount)
+	}
+	rightCodes, leftCodes, size := buildProbeCodes(rightKeys, leftKeys, nullsEqual)
+	return probeRows(leftCodes, rightCodes, size)
+}
+
+// partitionedHashMatch splits both sides into partitions by a hash of their keys, so that equal
+// keys fall in the same partition, and hash joins each partition on its own
+func partitionedHashMatch(leftKeys, rightKeys []*Series, nullsEqual bool, partitions int) ([]int, []int) {
+	seed := maphash.MakeSeed()
+	leftParts := partitionRows(leftKeys, seed, partitions)
+	rightParts := partitionRows(rightKeys, seed, partitions)
+
+	var leftRows, rightRows []int
+	for p := range partitions {
+		l, r := hashMatch(takeKeys(leftKeys, leftParts[p]), takeKeys(rightKeys, rightParts[p]), nullsEqual, 0)
+		for k := range l {
+			leftRows = append(leftRows, leftParts[p][l[k]])
+			rightRows = append(rightRows, rightParts[p][r[k]])
+		}
+	}
+	return sortPairs(leftRows, rightRows, leftKeys[0].Len())
+}
+
+// partitionRows assigns every row to one of the given number of partitions by a hash of its keys
+func partitionRows(keys []*Series, seed maphash.Seed, partitions int) [][]int {
+	parts := make([][]int, partitions)
+	for i, hash := range rowHashes(keys, seed) {
+		p := hash % uint64(partitions)
+		parts[p] = append(parts[p], i)
+	}
+	return parts
+}
+
+// takeKeys returns the rows at the given indices of every key column
+func takeKeys(keys []*Series, rows []int) []*Series {
+	taken := make([]*Series, len(keys))
+	for k, key := range keys {
+		taken[k] = key.take(rows)
+	}
+	return taken
+}
+
+// rowHashes returns a hash per row of the combined key values; equal keys hash equally, with
+// integers hashed as the equal floats
+func rowHashes(keys []*Series, seed maphash.Seed) []uint64 {
+	hashes := make([]uint64, keys[0].Len())
+	for _, key := range keys {
+		for i, hash := range valueHashes(key, seed) {
+			hashes[i] = mixHash(hashes[i] ^ hash)
+		}
+	}
+	return hashes
+}
+
+// valueHashes returns a hash per value of a Series, with 0 for missing values
+func valueHashes(s *Series, seed maphash.Seed) []uint64 {
+	hashes := make([]uint64, s.Len())
+	switch c := s.data.(type) {
+	case *vector[string]:
+		for i, x := range c.data {
+			if c.valid.get(i) {
+				hashes[i] = maphash.String(seed, x)
+			}
+		}
+	case *vector[bool]:
+		for i, x := range c.data {
+			if c.valid.get(i) && x {
+				hashes[i] = 2
+			} else if c.valid.get(i) {
+				hashes[i] = 1
+			}
+		}
+	case *vector[time.Time]:
+		for i, x := range c.data {
+			if c.valid.get(i) {
+				hashes[i] = uint64(x.Unix())*1_000_000_000 + uint64(x.Nanosecond())
+			}
+		}
+	default:
+		if values, valid, ok := s.float64s(); ok {
+			for i, x := range values {
+				if valid.get(i) {
+					hashes[i] = floatKey(x)
+				}
+			}
+		}
+	}
+	return hashes
+}
+
+// mixHash scrambles the bits of a hash so that nearby inputs spread across partitions
+func mixHash(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// buildProbeCodes returns a code per build and probe row identifying its key, along with the
+// number of codes. Only the build keys are stored in hash tables; probe rows whose key does not
+// occur on the build side get -1, as do rows with a missing key unless nullsEqual is set.
+func buildProbeCodes(buildKeys, probeKeys []*Series, nullsEqual bool) ([]int, []int, int) {
+	build := make([][]int, len(buildKeys))
+	probe := make([][]int, len(probeKeys))
+	for k := range buildKeys {
+		b, p := buildKeys[k], probeKeys[k]
+		f := newFactorizer()
+		encode := f.encode
+		if b.dtype != p.dtype && b.dtype.IsNumeric() && p.dtype.IsNumeric() {
+			encode = f.encodeFloats
+		}
+		build[k] = encode(b)
+		f.frozen = true
+		probe[k] = encode(p)
+	}
+
+	buildCodes, probeCodes, size := combineJoinCodes(build, probe)
+	if !nullsEqual {
+		for k := range buildKeys {
+			markNullKeys(buildCodes, buildKeys[k])
+			markNullKeys(probeCodes, probeKeys[k])
+		}
+	}
+	return buildCodes, probeCodes, size
+}
+
+// combineJoinCodes merges per-column build and probe codes into a single code per row; probe rows
+// whose combined key does not occur on the build side get -1
+func combineJoinCodes(build, probe [][]int) ([]int, []int, int) {
+	buildCodes := slices.Clone(build[0])
+	probeCodes := slices.Clone(probe[0])
+	size := 0
+	for _, code := range buildCodes {
+		size = max(size, code+1)
+	}
+
+	for k := 1; k < len(build); k++ {
+		pairs := make(map[[2]int]int)
+		for i, code := range buildCodes {
+			pair := [2]int{code, build[k][i]}
+			combined, ok := pairs[pair]
+			if !ok {
+				combined = len(pairs)
+				pairs[pair] = combined
+			}
+			buildCodes[i] = combined
+		}
+		for i, code := range probeCodes {
+			combined, ok := pairs[[2]int{code, probe[k][i]}]
+			if !ok {
+				combined = -1
+			}
+			probeCodes[i] = combined
+		}
+		size = len(pairs)
+	}
+	return buildCodes, probeCodes, size
+}
+
+// markNullKeys sets the code of every row where the key is missing to -1
+func markNullKeys(codes []int, key *Series) {
+	if key.NullCount() == 0 {
+		return
+	}
+	for i := range codes {
+		if key.IsNull(i) {
+			codes[i] = -1
+		}
+	}
+}
+
+// probeRows pairs every probe row with the build rows sharing its code, in probe order and then
+// build order
+func probeRows(probe, build []int, size int) ([]int, []int) {
+	head := repeatIndex(-1, size)
+	next := make([]int, len(build))
+	for j := len(build) - 1; j >= 0; j-- {
+		if code := build[j]; code >= 0 {
+			next[j] = head[code]
+			head[code] = j
+		}
+	}
+
+	probeMatches := make([]int, 0, len(probe))
+	buildMatches := make([]int, 0, len(probe))
+	for i, code := range probe {
+		if code < 0 {
+			continue
+		}
+		for j := head[code]; j >= 0; j = next[j] {
+			probeMatches = append(probeMatches, i)
+			buildMatches = append(buildMatches, j)
+		}
+	}
+	return probeMatches, buildMatches
+}
+
+// keysSorted reports whether the key columns are sorted in ascending order with missing values
+// last, comparing later columns where earlier ones are equal
+func keysSorted(keys []*Series) bool {
+	compares := make([]func(i, j int) int, len(keys))
+	for k, key := range keys {
+		compares[k] = nullsLast(key.comparator(), key, key)
+	}
+
+	for i := 1; i < keys[0].Len(); i++ {
+		if compareRows(compares, i-1, i) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// compareRows compares two rows column by column with the given comparators
+func compareRows(compares []func(i, j int) int, i, j int) int {
+	for _, compare := range compares {
+		if result := compare(i, j); result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+// nullsLast wraps a function ordering row i of a against row j of b so that missing values order
+// after all others and equal each other
+func nullsLast(compare func(i, j int) int, a, b *Series) func(i, j int) int {
+	if a.NullCount() == 0 && b.NullCount() == 0 {
+		return compare
+	}
+	return func(i, j int) int {
+		switch aNull, bNull := a.IsNull(i), b.IsNull(j); {
+		case aNull && bNull:
+			return 0
+		case aNull:
+			return 1
+		case bNull:
+			return -1
+		}
+		return compare(i, j)
+	}
+}
+
+// sortMergeMatch pairs rows with equal keys by walking two sides sorted on their keys in step.
+// Rows with a missing key only match when nullsEqual is set.
+func sortMergeMatch(leftKeys, rightKeys []*Series, nullsEqual bool) ([]int, []int) {
+	across := make([]func(i, j int) int, len(leftKeys))
+	leftCompares := make([]func(i, j int) int, len(leftKeys))
+	rightCompares := make([]func(i, j int) int, len(rightKeys))
+	for k := range leftKeys {
+		across[k] = nullsLast(compareAcross(leftKeys[k], rightKeys[k]), leftKeys[k], rightKeys[k])
+		leftCompares[k] = nullsLast(leftKeys[k].comparator(), leftKeys[k], leftKeys[k])
+		rightCompares[k] = nullsLast(rightKeys[k].comparator(), rightKeys[k], rightKeys[k])
+	}
+
+	leftCount, rightCount := leftKeys[0].Len(), rightKeys[0].Len()
+	var leftRows, rightRows []int
+	i, j := 0, 0
+	for i < leftCount && j < rightCount {
+		switch result := compareRows(across, i, j); {
+		case result < 0:
+			i++
+		case result > 0:
+			j++
+		default:
+			leftEnd, rightEnd := i+1, j+1
+			for leftEnd < leftCount && compareRows(leftCompares, i, leftEnd) == 0 {
+				leftEnd++
+			}
+			for rightEnd < rightCount && compareRows(rightCompares, j, rightEnd) == 0 {
+				rightEnd++
+			}
+			for a := i; a < leftEnd && (nullsEqual || !hasMissingKey(leftKeys, i)); a++ {
+				for b := j; b < rightEnd; b++ {
+					leftRows = append(leftRows, a)
+					rightRows = append(rightRows, b)
+				}
+			}
+			i, j = leftEnd, rightEnd
+		}
+	}
+	return leftRows, rightRows
+}
+
+// hasMissingKey reports whether any key column is missing at row i
+func hasMissingKey(keys []*Series, i int) bool {
+	for _, key := range keys {
+		if key.IsNull(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareAcross returns a function ordering row i of a against row j of b, which must hold
+// non-missing values of joinable types
+func compareAcross(a, b *Series) func(i, j int) int {
+	var (
+		compare func(i, j int) int
+		ok      bool
+	)
+	switch x := a.data.(type) {
+	case *vector[int64]:
+		compare, ok = compareVectors(x, b.data, cmp.Compare[int64])
+	case *vector[float64]:
+		compare, ok = compareVectors(x, b.data, cmp.Compare[float64])
+	case *vector[string]:
+		compare, ok = compareVectors(x, b.data, cmp.Compare[string])
+	case *vector[bool]:
+		compare, ok = compareVectors(x, b.data, compareBool)
+	case *vector[time.Time]:
+		compare, ok = compareVectors(x, b.data, time.Time.Compare)
+	}
+	if ok {
+		return compare
+	}
+
+	x, _, _ := a.float64s()
+	y, _, _ := b.float64s()
+	return func(i, j int) int { return cmp.Compare(x[i], y[j]) }
+}
+
+// compareVectors returns a function ordering row i of a against row j of b when b stores the
+// same element type
+func compareVectors[T any](a *vector[T], b column, compare func(x, y T) int) (func(i, j int) int, bool) {
+	y, ok := b.(*vector[T])
+	if !ok {
+		return nil, false
+	}
+	return func(i, j int) int { return compare(a.data[i], y.data[j]) }, true
+}
+
+// sortPairs stably reorders row pairs by their first row, which lies in [0, n)
+func sortPairs(first, second []int, n int) ([]int, []int) {
+	starts := make([]int, n+1)
+	for _, i := range first {
+		starts[i+1]++
+	}
+	for i := 1; i <= n; i++ {
+		starts[i] += starts[i-1]
+	}
+
+	sortedFirst := make([]int, len(first))
+	sortedSecond := make([]int, len(second))
+	for k, i := range first {
+		sortedFirst[starts[i]] = i
+		sortedSecond[starts[i]] = second[k]
+		starts[i]++
+	}
+	return sortedFirst, sortedSecond
+}
+
+// withUnmatched inserts a pair with -1 for every first row in [0, n) without a pair, given pairs
+// ordered by their first row
+func withUnmatched(first, second []int, n int) ([]int, []int) {
+	outFirst := make([]int, 0, len(first)+n)
+	outSecond := make([]int, 0, len(second)+n)
+	k := 0
+	for i := 0; i < n; i++ {
+		if k == len(first) || first[k] != i {
+			outFirst = append(outFirst, i)
+			outSecond = append(outSecond, -1)
+			continue
+		}
+		for k < len(first) && first[k] == i {
+			outFirst = append(outFirst, i)
+			outSecond = append(outSecond, second[k])
+			k++
+		}
+	}
+	return outFirst, outSecond
+}
@@ -0,0 +1,171 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+)
+
+// keySeries builds a key column from boxed values, failing the test on a type mismatch
+func keySeries(t testing.TB, name string, values ...interface{}) *Series {
+	t.Helper()
+	s, err := NewSeries(name, values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// bruteForcePairs pairs every left and right row with equal keys by comparing all of them, with
+// NaNs equal to each other and missing keys equal only when nullsEqual is set
+func bruteForcePairs(left, right *Series, nullsEqual bool) ([]int, []int) {
+	var leftRows, rightRows []int
+	for i := 0; i < left.Len(); i++ {
+		for j := 0; j < right.Len(); j++ {
+			a, b := left.Value(i), right.Value(j)
+			equal := false
+			switch {
+			case a == nil || b == nil:
+				equal = a == nil && b == nil && nullsEqual
+			default:
+				x, _ := toFloat64(a)
+				y, _ := toFloat64(b)
+				equal = x == y || (math.IsNaN(x) && math.IsNaN(y))
+			}
+			if equal {
+				leftRows = append(leftRows, i)
+				rightRows = append(rightRows, j)
+			}
+		}
+	}
+	return leftRows, rightRows
+}
+
+func TestJoinStrategiesAgree(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name        string
+		left, right *Series
+	}{
+		{
+			name:  "int64 against float64",
+			left:  keySeries(t, "key", int64(1), int64(2), int64(3), int64(5)),
+			right: keySeries(t, "key", 0.5, 2.0, 3.0, 4.0, 5.0),
+		},
+		{
+			name:  "duplicate keys",
+			left:  keySeries(t, "key", int64(1), int64(2), int64(2), int64(2), int64(4)),
+			right: keySeries(t, "key", int64(2), int64(2), int64(3), int64(4), int64(4)),
+		},
+		{
+			name:  "NaN keys",
+			left:  keySeries(t, "key", nan, nan, 1.0, 2.0),
+			right: keySeries(t, "key", nan, 1.0, 1.0, 3.0),
+		},
+		{
+			name:  "null keys",
+			left:  keySeries(t, "key", int64(1), int64(2), nil, nil),
+			right: keySeries(t, "key", 2.0, 2.0, nil),
+		},
+	}
+	strategies := []struct {
+		name string
+		opts MergeOptions
+	}{
+		{"hash", MergeOptions{Strategy: HashStrategy}},
+		{"sort-merge", MergeOptions{Strategy: SortMergeStrategy}},
+		{"partitioned hash", MergeOptions{Strategy: HashStrategy, MaxBuildRows: 1}},
+	}
+
+	for _, tt := range tests {
+		for _, nullsEqual := range []bool{false, true} {
+			wantLeft, wantRight := bruteForcePairs(tt.left, tt.right, nullsEqual)
+			for _, strategy := range strategies {
+				opts := strategy.opts
+				opts.NullsEqual = nullsEqual
+				t.Run(fmt.Sprintf("%s/%s/nullsEqual=%v", tt.name, strategy.name, nullsEqual), func(t *testing.T) {
+					leftRows, rightRows, err := matchRows([]*Series{tt.left}, []*Series{tt.right}, opts)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if !slices.Equal(leftRows, wantLeft) || !slices.Equal(rightRows, wantRight) {
+						t.Errorf("got pairs %v %v, want %v %v", leftRows, rightRows, wantLeft, wantRight)
+					}
+
+					// semi and anti joins keep the left rows with and without a match
+					matched, err := matchedRows([]*Series{tt.left}, []*Series{tt.right}, opts)
+					if err != nil {
+						t.Fatal(err)
+					}
+					wantMatched := make([]bool, tt.left.Len())
+					for _, i := range wantLeft {
+						wantMatched[i] = true
+					}
+					if !slices.Equal(matched, wantMatched) {
+						t.Errorf("got matched rows %v, want %v", matched, wantMatched)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestSemiAndAntiJoinStrategies(t *testing.T) {
+	left, err := NewDataFrameFromSeries(keySeries(t, "key", int64(2), int64(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	right, err := NewDataFrameFromSeries(keySeries(t, "key", int64(1), int64(3)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, how := range []JoinHow{SemiJoin, AntiJoin} {
+		if _, err := Merge(left, right, MergeOptions{On: []string{"key"}, How: how, Strategy: SortMergeStrategy}); err == nil {
+			t.Errorf("join %d: expected an error for unsorted sort-merge input", how)
+		}
+		if _, err := Merge(left, right, MergeOptions{On: []string{"key"}, How: how, Strategy: SortMergeStrategy + 1}); err == nil {
+			t.Errorf("join %d: expected an error for an unknown strategy", how)
+		}
+		if _, err := Merge(left, right, MergeOptions{On: []string{"key"}, How: how, Strategy: HashStrategy, MaxBuildRows: 1}); err != nil {
+			t.Errorf("join %d: %v", how, err)
+		}
+	}
+}
+
+// benchmarkFrames returns two DataFrames of n rows sorted on an Int64 key column, where left keys
+// repeat twice and right keys three times
+func benchmarkFrames(n int) (*DataFrame, *DataFrame) {
+	leftKeys, rightKeys := make([]int64, n), make([]int64, n)
+	values := make([]float64, n)
+	for i := range leftKeys {
+		leftKeys[i], rightKeys[i], values[i] = int64(i/2), int64(i/3), float64(i)
+	}
+	left, _ := NewDataFrameFromSeries(NewInt64Series("key", leftKeys), NewFloat64Series("left", values))
+	right, _ := NewDataFrameFromSeries(NewInt64Series("key", rightKeys), NewFloat64Series("right", values))
+	return left, right
+}
+
+func BenchmarkMerge(b *testing.B) {
+	for _, n := range []int{100_000, 1_000_000} {
+		left, right := benchmarkFrames(n)
+		strategies := []struct {
+			name string
+			opts MergeOptions
+		}{
+			{"hash", MergeOptions{On: []string{"key"}, Strategy: HashStrategy}},
+			{"sort-merge", MergeOptions{On: []string{"key"}, Strategy: SortMergeStrategy}},
+			{"partitioned hash", MergeOptions{On: []string{"key"}, Strategy: HashStrategy, MaxBuildRows: n / 8}},
+		}
+		for _, strategy := range strategies {
+			b.Run(fmt.Sprintf("%s/rows=%d", strategy.name, n), func(b *testing.B) {
+				for range b.N {
+					if _, err := Merge(left, right, strategy.opts); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,527 @@
+package dataframe
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// JSONOrient selects the layout WriteJSON writes and ReadJSON reads
+type JSONOrient int
+
+const (
+	// OrientRecords lays the DataFrame out as an array of objects, one per row:
+	// [{"a":1,"b":"x"},{"a":2,"b":"y"}]
+	OrientRecords JSONOrient = iota
+	// OrientColumns lays the DataFrame out as an object holding an array per column:
+	// {"a":[1,2],"b":["x","y"]}
+	OrientColumns
+	// OrientSplit lays the DataFrame out as an object holding the column names, their types and an
+	// array per row: {"columns":["a","b"],"types":["Int64","String"],"data":[[1,"x"],[2,"y"]]}
+	OrientSplit
+	// OrientValues lays the DataFrame out as an array per row, without column names:
+	// [[1,"x"],[2,"y"]]
+	OrientValues
+)
+
+// NaNPolicy selects how WriteJSON writes NaN and infinite floats, which JSON numbers cannot hold
+type NaNPolicy int
+
+const (
+	// NaNAsNull writes NaN and infinities as null
+	NaNAsNull NaNPolicy = iota
+	// NaNAsString writes NaN and infinities as the strings "NaN", "Infinity" and "-Infinity"
+	NaNAsString
+	// NaNAsError makes WriteJSON fail on NaN and infinities
+	NaNAsError
+)
+
+// JSONOptions configures WriteJSON and ReadJSON. TimeLayout formats and parses Datetime values,
+// defaulting to time.RFC3339Nano. Types fixes the type of the named columns when reading; the
+// others take the types recorded by OrientSplit or are inferred, where integers infer as Int64,
+// other numbers as Float64, text as String and true/false as Bool.
+type JSONOptions struct {
+	Orient     JSONOrient
+	NaN        NaNPolicy
+	TimeLayout string
+	Types      map[string]DType
+}
+
+// jsonSplit is the layout of OrientSplit
+type jsonSplit struct {
+	Columns []string        `json:"columns"`
+	Types   []string        `json:"types"`
+	Data    [][]interface{} `json:"data"`
+}
+
+// WriteJSON writes the DataFrame to w as JSON in the chosen orientation, one row or column at a
+// time. Missing values are written as null.
+func (df *DataFrame) WriteJSON(w io.Writer, opts JSONOptions) error {
+	if opts.TimeLayout == "" {
+		opts.TimeLayout = time.RFC3339Nano
+	}
+	writer := bufio.NewWriter(w)
+	var buf []byte
+	var err error
+
+	switch opts.Orient {
+	case OrientRecords:
+		writer.WriteByte('[')
+		for i := 0; i < df.RowCount(); i++ {
+			buf = buf[:0]
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = append(buf, '{')
+			for c, s := range df.columns {
+				if c > 0 {
+					buf = append(buf, ',')
+				}
+				buf = append(appendJSONString(buf, s.name), ':')
+				if buf, err = appendJSONValue(buf, s, i, opts); err != nil {
+					return err
+				}
+			}
+			writer.Write(append(buf, '}'))
+		}
+		writer.WriteByte(']')
+	case OrientColumns:
+		writer.WriteByte('{')
+		for c, s := range df.columns {
+			buf = buf[:0]
+			if c > 0 {
+				buf = append(buf, ',')
+			}
+			buf = append(appendJSONString(buf, s.name), ':', '[')
+			for i := 0; i < s.Len(); i++ {
+				if i > 0 {
+					buf = append(buf, ',')
+				}
+				if buf, err = appendJSONValue(buf, s, i, opts); err != nil {
+					return err
+				}
+			}
+			writer.Write(append(buf, ']'))
+		}
+		writer.WriteByte('}')
+	case OrientSplit:
+		buf = append(buf, `{"columns":[`...)
+		for c, s := range df.columns {
+			if c > 0 {
+				buf = append(buf, ',')
+			}
+			buf = appendJSONString(buf, s.name)
+		}
+		buf = append(buf, `],"types":[`...)
+		for c, s := range df.columns {
+			if c > 0 {
+				buf = append(buf, ',')
+			}
+			buf = appendJSONString(buf, s.dtype.String())
+		}
+		writer.Write(append(buf, `],"data":`...))
+		if err := df.writeJSONRows(writer, opts); err != nil {
+			return err
+		}
+		writer.WriteByte('}')
+	case OrientValues:
+		if err := df.writeJSONRows(writer, opts); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown JSON orientation %d", opts.Orient)
+	}
+	return writer.Flush()
+}
+
+// writeJSONRows writes the rows of the DataFrame as an array of arrays
+func (df *DataFrame) writeJSONRows(writer *bufio.Writer, opts JSONOptions) error {
+	writer.WriteByte('[')
+	var buf []byte
+	var err error
+	for i := 0; i < df.RowCount(); i++ {
+		buf = buf[:0]
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, '[')
+		for c, s := range df.columns {
+			if c > 0 {
+				buf = append(buf, ',')
+			}
+			if buf, err = appendJSONValue(buf, s, i, opts); err != nil {
+				return err
+			}
+		}
+		writer.Write(append(buf, ']'))
+	}
+	return writer.WriteByte(']')
+}
+
+// appendJSONValue appends the JSON form of row i of a Series
+func appendJSONValue(buf []byte, s *Series, i int, opts JSONOptions) ([]byte, error) {
+	switch v := s.Value(i).(type) {
+	case nil:
+		return append(buf, "null"...), nil
+	case string:
+		return appendJSONString(buf, v), nil
+	case int64:
+		return strconv.AppendInt(buf, v, 10), nil
+	case float64:
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			return append(buf, formatFloat(v)...), nil
+		}
+		switch opts.NaN {
+		case NaNAsNull:
+			return append(buf, "null"...), nil
+		case NaNAsString:
+			return appendJSONString(buf, nonFiniteName(v)), nil
+		case NaNAsError:
+			return nil, fmt.Errorf("column '%s' holds %v at row %d, which JSON cannot represent", s.name, v, i)
+		}
+		return nil, fmt.Errorf("unknown NaN policy %d", opts.NaN)
+	case bool:
+		return strconv.AppendBool(buf, v), nil
+	case time.Time:
+		return appendJSONString(buf, v.Format(opts.TimeLayout)), nil
+	}
+	return nil, fmt.Errorf("column '%s' holds unsupported value %v at row %d", s.name, s.Value(i), i)
+}
+
+// appendJSONString appends a string as a quoted and escaped JSON string
+func appendJSONString(buf []byte, s string) []byte {
+	quoted, _ := json.Marshal(s)
+	return append(buf, quoted...)
+}
+
+// nonFiniteName returns the name NaNAsString writes for NaN or an infinity
+func nonFiniteName(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "Infinity"
+	case math.IsInf(v, -1):
+		return "-Infinity"
+	}
+	return "NaN"
+}
+
+// parseNonFinite returns the float named by a string NaNAsString writes
+func parseNonFinite(s string) (float64, bool) {
+	switch s {
+	case "NaN":
+		return math.NaN(), true
+	case "Infinity":
+		return math.Inf(1), true
+	case "-Infinity":
+		return math.Inf(-1), true
+	}
+	return 0, false
+}
+
+// ReadJSON reads a DataFrame from JSON in the chosen orientation. Floats may be given as the
+// strings NaNAsString writes. Records lacking a key hold missing values in that column, and
+// OrientValues names the columns column_0, column_1 and so on.
+func ReadJSON(r io.Reader, opts JSONOptions) (*DataFrame, error) {
+	if opts.TimeLayout == "" {
+		opts.TimeLayout = time.RFC3339Nano
+	}
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var names []string
+	var values [][]interface{}
+	var types []string
+	var err error
+	switch opts.Orient {
+	case OrientRecords:
+		names, values, err = decodeJSONRecords(decoder)
+	case OrientColumns:
+		names, values, err = decodeJSONColumns(decoder)
+	case OrientSplit:
+		var split jsonSplit
+		if err = decoder.Decode(&split); err != nil {
+			break
+		}
+		if split.Types != nil && len(split.Types) != len(split.Columns) {
+			return nil, fmt.Errorf("JSON names %d columns but %d types", len(split.Columns), len(split.Types))
+		}
+		names, types = split.Columns, split.Types
+		values, err = transposeJSONRows(split.Data, len(names))
+	case OrientValues:
+		var rows [][]interface{}
+		if err = decoder.Decode(&rows); err != nil {
+			break
+		}
+		width := 0
+		if len(rows) > 0 {
+			width = len(rows[0])
+		}
+		names = make([]string, width)
+		for c := range names {
+			names[c] = fmt.Sprintf("column_%d", c)
+		}
+		values, err = transposeJSONRows(rows, width)
+	default:
+		return nil, fmt.Errorf("unknown JSON orientation %d", opts.Orient)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after JSON value")
+	}
+
+	header := make(Schema, len(names))
+	for c, name := range names {
+		header[c] = Field{Name: name}
+	}
+	if len(names) > 0 {
+		if err := header.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	for columnName := range opts.Types {
+		if _, ok := header.Field(columnName); !ok {
+			return nil, fmt.Errorf("column '%s' does not exist", columnName)
+		}
+	}
+
+	columns := make([]*Series, len(names))
+	for c, name := range names {
+		dtype, declared := opts.Types[name]
+		if !declared && types != nil {
+			if dtype, declared = parseDType(types[c]); !declared {
+				return nil, fmt.Errorf("column '%s' has unknown type %q", name, types[c])
+			}
+		}
+		if columns[c], err = jsonSeries(name, values[c], dtype, declared, opts.TimeLayout); err != nil {
+			return nil, err
+		}
+	}
+	return frameOf(columns), nil
+}
+
+// decodeJSONRecords decodes an array of objects into columns named in order of first appearance
+func decodeJSONRecords(decoder *json.Decoder) ([]string, [][]interface{}, error) {
+	if err := expectJSONDelim(decoder, '['); err != nil {
+		return nil, nil, err
+	}
+	var names []string
+	var values [][]interface{}
+	index := make(map[string]int)
+	for row := 0; decoder.More(); row++ {
+		if err := expectJSONDelim(decoder, '{'); err != nil {
+			return nil, nil, fmt.Errorf("record %d: %w", row, err)
+		}
+		for decoder.More() {
+			key, value, err := decodeJSONMember(decoder)
+			if err != nil {
+				return nil, nil, err
+			}
+			c, ok := index[key]
+			if !ok {
+				c = len(names)
+				index[key] = c
+				names = append(names, key)
+				values = append(values, make([]interface{}, row, row+1))
+			}
+			if len(values[c]) > row {
+				values[c][row] = value
+			} else {
+				values[c] = append(values[c], value)
+			}
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, nil, err
+		}
+		for c := range values {
+			if len(values[c]) == row {
+				values[c] = append(values[c], nil)
+			}
+		}
+	}
+	_, err := decoder.Token()
+	return names, values, err
+}
+
+// decodeJSONColumns decodes an object of arrays into columns named in order of appearance
+func decodeJSONColumns(decoder *json.Decoder) ([]string, [][]interface{}, error) {
+	if err := expectJSONDelim(decoder, '{'); err != nil {
+		return nil, nil, err
+	}
+	var names []string
+	var values [][]interface{}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		var column []interface{}
+		if err := decoder.Decode(&column); err != nil {
+			return nil, nil, fmt.Errorf("column '%s': %w", token, err)
+		}
+		if len(values) > 0 && len(column) != len(values[0]) {
+			return nil, nil, fmt.Errorf("column '%s' has %d values, expected %d", token, len(column), len(values[0]))
+		}
+		names = append(names, token.(string))
+		values = append(values, column)
+	}
+	_, err := decoder.Token()
+	return names, values, err
+}
+
+// decodeJSONMember decodes the next key and value of an object
+func decodeJSONMember(decoder *json.Decoder) (string, interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return "", nil, err
+	}
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return "", nil, err
+	}
+	return token.(string), value, nil
+}
+
+// expectJSONDelim reads the next token, failing unless it is the given delimiter
+func expectJSONDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %v in JSON, found %v", delim, token)
+	}
+	return nil
+}
+
+// transposeJSONRows turns rows of values into columns, checking that every row has width values
+func transposeJSONRows(rows [][]interface{}, width int) ([][]interface{}, error) {
+	values := make([][]interface{}, width)
+	for c := range values {
+		values[c] = make([]interface{}, len(rows))
+	}
+	for i, row := range rows {
+		if len(row) != width {
+			return nil, fmt.Errorf("row %d has %d values, expected %d", i, len(row), width)
+		}
+		for c, value := range row {
+			values[c][i] = value
+		}
+	}
+	return values, nil
+}
+
+// jsonSeries builds a Series from decoded JSON values, inferring its type unless declared
+func jsonSeries(name string, values []interface{}, dtype DType, declared bool, layout string) (*Series, error) {
+	if !declared {
+		var err error
+		if dtype, err = inferJSONType(name, values); err != nil {
+			return nil, err
+		}
+	}
+
+	col := newColumn(dtype, len(values))
+	for i, value := range values {
+		converted, ok := value, true
+		switch v := value.(type) {
+		case json.Number:
+			switch dtype {
+			case Int64:
+				converted, ok = toJSONResult(v.Int64())
+			case Float64:
+				converted, ok = toJSONResult(v.Float64())
+			}
+		case string:
+			switch dtype {
+			case Float64:
+				converted, ok = parseNonFinite(v)
+			case Datetime:
+				converted, ok = toJSONResult(time.Parse(layout, v))
+			}
+		}
+		if !ok || !col.appendValue(converted) {
+			return nil, &TypeMismatchError{Column: name, Row: i, Expected: dtype, Value: value}
+		}
+	}
+	return &Series{name: name, dtype: dtype, data: col}, nil
+}
+
+// toJSONResult boxes a converted value, reporting whether the conversion succeeded
+func toJSONResult[T any](value T, err error) (interface{}, bool) {
+	return value, err == nil
+}
+
+// inferJSONType returns the type of a column of decoded JSON values. The strings NaNAsString writes
+// count as floats unless the column holds other text.
+func inferJSONType(name string, values []interface{}) (DType, error) {
+	inferred, nonFinite := Null, false
+	for i, value := range values {
+		t := Null
+		switch v := value.(type) {
+		case nil:
+			continue
+		case json.Number:
+			t = Float64
+			if _, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+				t = Int64
+			}
+		case string:
+			if _, ok := parseNonFinite(v); ok {
+				nonFinite = true
+				continue
+			}
+			t = String
+		case bool:
+			t = Bool
+		default:
+			return Null, fmt.Errorf("column '%s' holds a nested JSON value at row %d", name, i)
+		}
+
+		switch {
+		case inferred == Null || inferred == t:
+			inferred = t
+		case inferred.IsNumeric() && t.IsNumeric():
+			inferred = Float64
+		default:
+			return Null, fmt.Errorf("column '%s' mixes %s and %s values", name, inferred, t)
+		}
+	}
+
+	if nonFinite {
+		switch inferred {
+		case Null, Int64, Float64:
+			return Float64, nil
+		case String:
+			return String, nil
+		}
+		return Null, fmt.Errorf("column '%s' mixes %s and String values", name, inferred)
+	}
+	return inferred, nil
+}
+
+// MarshalJSON encodes the DataFrame in the split orientation, recording column types and writing
+// NaN and infinities as strings, so that UnmarshalJSON restores it exactly
+func (df *DataFrame) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := df.WriteJSON(&buf, JSONOptions{Orient: OrientSplit, NaN: NaNAsString}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a DataFrame in the split orientation, replacing the receiver's contents
+func (df *DataFrame) UnmarshalJSON(data []byte) error {
+	decoded, err := ReadJSON(bytes.NewReader(data), JSONOptions{Orient: OrientSplit})
+	if err != nil {
+		return err
+	}
+	*df = *decoded
+	return nil
+}
@@ -13,18 +13,17 @@ func main() {
 		return
 	}
 
-	// Add columns to the DataFrame
-	names := []interface{}{"John", "Alice", "Bob", "Jane"}
-	df.AddColumn("Name", names)
-
-	ages := []interface{}{25, 30, 35, 28}
-	df.AddColumn("Age", ages)
-
-	cities := []interface{}{"New York", "London", "Tokyo", "Paris"}
-	df.AddColumn("City", cities)
-
-	salaries := []interface{}{50000, 60000, 75000, 55000}
-	df.AddColumn("Salary", salaries)
+	// Add rows to the DataFrame
+	df, err = df.AppendRows([]map[string]interface{}{
+		{"Name": "John", "Age": 25, "City": "New York", "Salary": 50000},
+		{"Name": "Alice", "Age": 30, "City": "London", "Salary": 60000},
+		{"Name": "Bob", "Age": 35, "City": "Tokyo", "Salary": 75000},
+		{"Name": "Jane", "Age": 28, "City": "Paris", "Salary": 55000},
+	})
+	if err != nil {
+		fmt.Println("Error adding rows:", err)
+		return
+	}
 
 	// Perform operations on the DataFrame
 
@@ -66,7 +65,8 @@ func main() {
 
 	// Filter the DataFrame
 	filteredDF, err := df.Filter(func(row int) bool {
-		return df.columns["Age"][row].(int) > 28
+		age, err := dataframe.At[int64](df, row, "Age")
+		return err == nil && age > 28
 	})
 	if err != nil {
 		fmt.Println("Error filtering DataFrame:", err)
@@ -79,24 +79,33 @@ func main() {
 	fmt.Println()
 
 	// Sort the DataFrame
-	err = df.Sort([]string{"Age", "Salary"}, true)
+	sortedDF, err := df.Sort([]dataframe.SortKey{{Col: "Age"}, {Col: "Salary"}})
 	if err != nil {
 		fmt.Println("Error sorting DataFrame:", err)
 		return
 	}
 
 	fmt.Println("Sorted DataFrame:")
-	df.PrintHeader()
-	df.PrintData()
+	sortedDF.PrintHeader()
+	sortedDF.PrintData()
 	fmt.Println()
 
 	// Group and aggregate the DataFrame
-	groupedDF, err := df.GroupBy([]string{"City"})
+	grouped, err := df.GroupBy("City")
 	if err != nil {
 		fmt.Println("Error grouping DataFrame:", err)
 		return
 	}
 
+	groupedDF, err := grouped.Agg(map[string][]dataframe.Agg{
+		"Name":   {{Func: dataframe.AggCount, Name: "Count"}},
+		"Salary": {{Func: dataframe.AggMean}, {Func: dataframe.AggMax}},
+	})
+	if err != nil {
+		fmt.Println("Error aggregating DataFrame:", err)
+		return
+	}
+
 	fmt.Println("Grouped DataFrame:")
 	groupedDF.PrintHeader()
 	groupedDF.PrintData()
@@ -109,14 +118,14 @@ func main() {
 		return
 	}
 
-	joinNames := []interface{}{"John", "Alice"}
-	joinDF1.AddColumn("Name", joinNames)
-
-	joinAges := []interface{}{25, 30}
-	joinDF1.AddColumn("Age", joinAges)
-
-	joinCities := []interface{}{"New York", "London"}
-	joinDF1.AddColumn("City", joinCities)
+	joinDF1, err = joinDF1.AppendRows([]map[string]interface{}{
+		{"Name": "John", "Age": 25, "City": "New York"},
+		{"Name": "Alice", "Age": 30, "City": "London"},
+	})
+	if err != nil {
+		fmt.Println("Error adding rows to join DataFrame 1:", err)
+		return
+	}
 
 	joinDF2, err := dataframe.NewDataFrame([]string{"City", "Population"})
 	if err != nil {
@@ -124,13 +133,16 @@ func main() {
 		return
 	}
 
-	joinCities2 := []interface{}{"New York", "London"}
-	joinDF2.AddColumn("City", joinCities2)
-
-	joinPopulations := []interface{}{8500000, 9000000}
-	joinDF2.AddColumn("Population", joinPopulations)
+	joinDF2, err = joinDF2.AppendRows([]map[string]interface{}{
+		{"City": "New York", "Population": 8500000},
+		{"City": "London", "Population": 9000000},
+	})
+	if err != nil {
+		fmt.Println("Error adding rows to join DataFrame 2:", err)
+		return
+	}
 
-	joinedDF, err := dataframe.Join([]*dataframe.DataFrame{joinDF1, joinDF2}, []string{"City"})
+	joinedDF, err := dataframe.Merge(joinDF1, joinDF2, dataframe.MergeOptions{On: []string{"City"}, How: dataframe.LeftJoin})
 	if err != nil {
 		fmt.Println("Error joining DataFrames:", err)
 		return
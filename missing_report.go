@@ -0,0 +1,112 @@
+package dataframe
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// MissingReport returns a DataFrame profiling the missing values of this one, with a row per
+// column holding its name in "column", its number of missing values in "null_count", their share
+// of the rows in "null_ratio", and, in a "nulls_with_<name>" column per column, the number of rows
+// where both columns are missing. A final row labelled "<any>" counts the rows missing a value in
+// any column. Missing values are those Count leaves out, so NaNs are values. Ratios are missing
+// when the DataFrame has no rows.
+func (df *DataFrame) MissingReport() *DataFrame {
+	rowCount := df.RowCount()
+	nulls := make([][]bool, len(df.columns))
+	for c, s := range df.columns {
+		if s.NullCount() == 0 {
+			continue
+		}
+		nulls[c] = make([]bool, rowCount)
+		for i := range nulls[c] {
+			nulls[c][i] = s.IsNull(i)
+		}
+	}
+
+	reportRows := len(df.columns) + 1
+	names := make([]string, reportRows)
+	counts := make([]int64, reportRows)
+	ratios := makeVector(make([]float64, reportRows), newBitmap(reportRows, rowCount > 0))
+	together := make([]*vector[int64], len(df.columns))
+	for c := range together {
+		together[c] = makeVector(make([]int64, reportRows), newBitmap(reportRows, true))
+		together[c].valid.set(len(df.columns), false)
+	}
+
+	anyNull := make([]bool, rowCount)
+	for c, s := range df.columns {
+		names[c] = s.name
+		counts[c] = int64(s.NullCount())
+		if nulls[c] == nil {
+			continue
+		}
+		for i, null := range nulls[c] {
+			if !null {
+				continue
+			}
+			anyNull[i] = true
+			for other := range df.columns {
+				if nulls[other] != nil && nulls[other][i] {
+					together[other].data[c]++
+				}
+			}
+		}
+	}
+
+	names[len(df.columns)] = "<any>"
+	for _, null := range anyNull {
+		if null {
+			counts[len(df.columns)]++
+		}
+	}
+	for r, count := range counts {
+		if rowCount > 0 {
+			ratios.data[r] = float64(count) / float64(rowCount)
+		}
+	}
+
+	columns := []*Series{
+		NewStringSeries("column", names),
+		NewInt64Series("null_count", counts),
+		{name: "null_ratio", dtype: Float64, data: ratios},
+	}
+	for c, s := range df.columns {
+		columns = append(columns, &Series{name: "nulls_with_" + s.name, dtype: Int64, data: together[c]})
+	}
+	return frameOf(columns)
+}
+
+// NullityMatrix renders which values of the DataFrame are present as text, with a header of column
+// names and a line per row where '#' marks a value and '.' a missing one, followed by the number
+// of values the row holds
+func (df *DataFrame) NullityMatrix() string {
+	widths := make([]int, len(df.columns))
+	var b strings.Builder
+	for c, s := range df.columns {
+		widths[c] = max(utf8.RuneCountInString(s.name), 1)
+		b.WriteString(s.name)
+		b.WriteByte(' ')
+	}
+	b.WriteByte('\n')
+
+	digits := len(fmt.Sprint(len(df.columns)))
+	for i := 0; i < df.RowCount(); i++ {
+		present := 0
+		for c, s := range df.columns {
+			mark := "#"
+			if s.IsNull(i) {
+				mark = "."
+			} else {
+				present++
+			}
+			padding := widths[c] - 1
+			b.WriteString(strings.Repeat(" ", padding/2))
+			b.WriteString(mark)
+			b.WriteString(strings.Repeat(" ", padding-padding/2+1))
+		}
+		fmt.Fprintf(&b, "%*d\n", digits, present)
+	}
+	return b.String()
+}
@@ -1,6 +1,404 @@
 package dataframe
 
-// Function to handle missing values by imputing them
-func (df *DataFrame) ImputeMissingValues() {
-	// Implement your missing value imputation logic here
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// DropHow selects which rows DropNulls removes
+type DropHow int
+
+const (
+	// DropAny removes a row when any of the considered columns is missing
+	DropAny DropHow = iota
+	// DropAll removes a row only when every considered column is missing
+	DropAll
+)
+
+// IsNull returns a mask that is true for every row where the column is missing
+func (df *DataFrame) IsNull(columnName string) ([]bool, error) {
+	s, err := df.Column(columnName)
+	if err != nil {
+		return nil, err
+	}
+
+	mask := make([]bool, s.Len())
+	for i := range mask {
+		mask[i] = s.IsNull(i)
+	}
+	return mask, nil
+}
+
+// NotNull returns a mask that is true for every row where the column holds a value
+func (df *DataFrame) NotNull(columnName string) ([]bool, error) {
+	mask, err := df.IsNull(columnName)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range mask {
+		mask[i] = !mask[i]
+	}
+	return mask, nil
+}
+
+// DropNulls returns a new DataFrame without the rows that have missing values in the subset
+// of columns; an empty subset considers every column
+func (df *DataFrame) DropNulls(subset []string, how DropHow) (*DataFrame, error) {
+	if how != DropAny && how != DropAll {
+		return nil, errors.New("invalid drop mode")
+	}
+
+	considered := df.columns
+	if len(subset) > 0 {
+		considered = make([]*Series, len(subset))
+		for i, columnName := range subset {
+			s, err := df.Column(columnName)
+			if err != nil {
+				return nil, err
+			}
+			considered[i] = s
+		}
+	}
+
+	return df.Filter(func(row int) bool {
+		missing := 0
+		for _, s := range considered {
+			if s.IsNull(row) {
+				missing++
+			}
+		}
+		if how == DropAll {
+			return missing < len(considered)
+		}
+		return missing == 0
+	})
+}
+
+// FillNull returns a new DataFrame with missing values replaced by the given value in every column
+// whose type can hold it; columns of another type are left unchanged, and columns with no type yet
+// take the type of the value
+func (df *DataFrame) FillNull(value interface{}) (*DataFrame, error) {
+	if value == nil {
+		return nil, errors.New("fill value must not be nil")
+	}
+	valueType, ok := dtypeOf(value)
+	if !ok {
+		return nil, fmt.Errorf("unsupported fill value type %T", value)
+	}
+
+	filled := &DataFrame{index: make(map[string]int, len(df.columns))}
+	for _, s := range df.columns {
+		if s.NullCount() == 0 {
+			filled.appendSeries(s)
+			continue
+		}
+
+		dtype, data := s.dtype, s.data
+		if dtype == Null {
+			dtype, data = valueType, newColumn(valueType, 0)
+			for i := 0; i < s.Len(); i++ {
+				data.appendNull()
+			}
+		}
+		if col, ok := data.fillNull(value); ok {
+			filled.appendSeries(&Series{name: s.name, dtype: dtype, data: col})
+		} else {
+			filled.appendSeries(s)
+		}
+	}
+	return filled, nil
+}
+
+// ImputeStrategy selects how Impute computes replacements for missing values
+type ImputeStrategy int
+
+const (
+	// ImputeMean fills a numeric column with the mean of its values
+	ImputeMean ImputeStrategy = iota
+	// ImputeMedian fills a numeric column with the median of its values
+	ImputeMedian
+	// ImputeMode fills a column with its most frequent value, preferring the earliest on a tie
+	ImputeMode
+	// ImputeConstant fills a column with a given value
+	ImputeConstant
+	// ImputeForwardFill fills a missing value with the closest value above it
+	ImputeForwardFill
+	// ImputeBackFill fills a missing value with the closest value below it
+	ImputeBackFill
+	// ImputeLinear interpolates a numeric column linearly between the closest values above and
+	// below, treating rows as evenly spaced
+	ImputeLinear
+	// ImputeTime interpolates a numeric column linearly between the closest values above and
+	// below, spacing rows by the times in a Datetime column
+	ImputeTime
+)
+
+var imputeStrategyNames = [...]string{
+	ImputeMean:        "mean",
+	ImputeMedian:      "median",
+	ImputeMode:        "mode",
+	ImputeConstant:    "constant",
+	ImputeForwardFill: "ffill",
+	ImputeBackFill:    "bfill",
+	ImputeLinear:      "linear",
+	ImputeTime:        "time",
+}
+
+// String returns the name of the strategy
+func (s ImputeStrategy) String() string {
+	if s >= 0 && int(s) < len(imputeStrategyNames) {
+		return imputeStrategyNames[s]
+	}
+	return fmt.Sprintf("ImputeStrategy(%d)", int(s))
+}
+
+// ImputeSpec describes how to fill the missing values of one column. Value is the fill value of
+// ImputeConstant and TimeColumn the Datetime column spacing rows for ImputeTime. When GroupBy
+// names columns, replacements are computed within each group of rows sharing their values, so
+// that for example each region is filled with its own median.
+type ImputeSpec struct {
+	Column     string
+	Strategy   ImputeStrategy
+	Value      interface{}
+	GroupBy    []string
+	TimeColumn string
+}
+
+// ImputeResult records the effect of one ImputeSpec: how many missing values it filled and how
+// many remain, such as values before the first one a forward fill can copy
+type ImputeResult struct {
+	Column    string
+	Strategy  ImputeStrategy
+	Filled    int
+	Remaining int
+}
+
+// ImputeReport lists the result of every ImputeSpec applied by Impute, in order
+type ImputeReport []ImputeResult
+
+// ByStrategy returns the number of values filled by each strategy
+func (r ImputeReport) ByStrategy() map[ImputeStrategy]int {
+	filled := make(map[ImputeStrategy]int)
+	for _, result := range r {
+		filled[result.Strategy] += result.Filled
+	}
+	return filled
+}
+
+// Impute returns a new DataFrame with missing values filled according to the given specs, applied
+// in order so that later specs see the values filled by earlier ones, together with a report of
+// what each spec filled. Mean, median and interpolation turn Int64 columns into Float64 columns.
+func (df *DataFrame) Impute(specs ...ImputeSpec) (*DataFrame, ImputeReport, error) {
+	if len(specs) == 0 {
+		return nil, nil, errors.New("at least one imputation spec is required")
+	}
+
+	imputed := df
+	report := make(ImputeReport, 0, len(specs))
+	for _, spec := range specs {
+		i, ok := imputed.index[spec.Column]
+		if !ok {
+			return nil, nil, fmt.Errorf("column '%s' does not exist", spec.Column)
+		}
+
+		s := imputed.columns[i]
+		filled, err := imputed.impute(s, spec)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		report = append(report, ImputeResult{
+			Column:    spec.Column,
+			Strategy:  spec.Strategy,
+			Filled:    s.NullCount() - filled.NullCount(),
+			Remaining: filled.NullCount(),
+		})
+		imputed = imputed.withColumn(i, filled)
+	}
+	return imputed, report, nil
+}
+
+// impute returns a copy of a Series of this DataFrame with missing values filled according to spec
+func (df *DataFrame) impute(s *Series, spec ImputeSpec) (*Series, error) {
+	groups := [][]int{rowRange(df.RowCount())}
+	if len(spec.GroupBy) > 0 {
+		grouped, err := df.GroupBy(spec.GroupBy...)
+		if err != nil {
+			return nil, err
+		}
+		groups = grouped.groups
+	}
+
+	switch spec.Strategy {
+	case ImputeMean, ImputeMedian, ImputeLinear, ImputeTime:
+		if !s.dtype.IsNumeric() {
+			return nil, fmt.Errorf("column '%s' is not numeric", s.name)
+		}
+		return df.imputeNumeric(s, spec, groups)
+	case ImputeMode, ImputeConstant, ImputeForwardFill, ImputeBackFill:
+		return imputeValues(s, spec, groups)
+	}
+	return nil, fmt.Errorf("unknown imputation strategy %s", spec.Strategy)
+}
+
+// imputeNumeric fills a numeric Series with statistics or interpolations of its values within
+// each group, returning a Float64 Series
+func (df *DataFrame) imputeNumeric(s *Series, spec ImputeSpec, groups [][]int) (*Series, error) {
+	var times *vector[int64]
+	if spec.Strategy == ImputeTime {
+		t, err := df.Column(spec.TimeColumn)
+		if err != nil {
+			return nil, err
+		}
+		if t.dtype != Datetime {
+			return nil, fmt.Errorf("column '%s' holds %s values, not %s", t.name, t.dtype, Datetime)
+		}
+		times = unixNanos(t)
+	}
+
+	out := s.asFloat64().data.Clone().(*vector[float64])
+	fill := func(i int, value float64) {
+		out.data[i] = value
+		out.valid.set(i, true)
+	}
+
+	for _, rows := range groups {
+		switch spec.Strategy {
+		case ImputeMean, ImputeMedian:
+			present := make([]float64, 0, len(rows))
+			for _, i := range rows {
+				if out.valid.get(i) {
+					present = append(present, out.data[i])
+				}
+			}
+			if len(present) == 0 || len(present) == len(rows) {
+				continue
+			}
+
+			value := mean(present)
+			if spec.Strategy == ImputeMedian {
+				slices.Sort(present)
+				value = quantile(present, 0.5)
+			}
+			for _, i := range rows {
+				if !out.valid.get(i) {
+					fill(i, value)
+				}
+			}
+
+		case ImputeLinear, ImputeTime:
+			position := func(k int) (float64, bool) { return float64(k), true }
+			if times != nil {
+				position = func(k int) (float64, bool) { return float64(times.data[rows[k]]), times.valid.get(rows[k]) }
+			}
+			interpolate(out, rows, position, fill)
+		}
+	}
+	return &Series{name: s.name, dtype: Float64, data: out}, nil
+}
+
+// interpolate fills every missing value among the given rows that lies between two present
+// values, weighting them by the distance between positions. Rows without a position are neither
+// filled nor used as anchors.
+func interpolate(v *vector[float64], rows []int, position func(k int) (float64, bool), fill func(i int, value float64)) {
+	previous := -1
+	for k := 0; k < len(rows); k++ {
+		if _, ok := position(k); !ok || !v.valid.get(rows[k]) {
+			continue
+		}
+
+		if previous >= 0 && k > previous+1 {
+			start, _ := position(previous)
+			end, _ := position(k)
+			from, to := v.data[rows[previous]], v.data[rows[k]]
+			for m := previous + 1; m < k; m++ {
+				at, ok := position(m)
+				if !ok {
+					continue
+				}
+				value := from
+				if end != start {
+					value = from + (to-from)*(at-start)/(end-start)
+				}
+				fill(rows[m], value)
+			}
+		}
+		previous = k
+	}
+}
+
+// imputeValues fills a Series of any type with its mode, a constant, or neighbouring values
+// within each group
+func imputeValues(s *Series, spec ImputeSpec, groups [][]int) (*Series, error) {
+	dtype, data := s.dtype, s.data.Clone()
+	if spec.Strategy == ImputeConstant {
+		if spec.Value == nil {
+			return nil, errors.New("constant imputation requires a fill value")
+		}
+		if dtype == Null {
+			var ok bool
+			if dtype, ok = dtypeOf(spec.Value); !ok {
+				return nil, fmt.Errorf("unsupported fill value type %T", spec.Value)
+			}
+			data = newColumn(dtype, s.Len())
+			for i := 0; i < s.Len(); i++ {
+				data.appendNull()
+			}
+		}
+	}
+
+	var codes []int
+	if spec.Strategy == ImputeMode {
+		codes = newFactorizer().encode(s)
+	}
+
+	for _, rows := range groups {
+		switch spec.Strategy {
+		case ImputeMode:
+			counts := make(map[int]int)
+			for _, i := range rows {
+				if !s.IsNull(i) {
+					counts[codes[i]]++
+				}
+			}
+			mode := -1
+			for _, i := range rows {
+				if !s.IsNull(i) && (mode < 0 || counts[codes[i]] > counts[codes[mode]]) {
+					mode = i
+				}
+			}
+			if mode < 0 {
+				continue
+			}
+			for _, i := range rows {
+				if s.IsNull(i) {
+					data.set(i, s.Value(mode))
+				}
+			}
+
+		case ImputeConstant:
+			for _, i := range rows {
+				if s.IsNull(i) && !data.set(i, spec.Value) {
+					return nil, &TypeMismatchError{Column: s.name, Row: i, Expected: dtype, Value: spec.Value}
+				}
+			}
+
+		case ImputeForwardFill, ImputeBackFill:
+			if spec.Strategy == ImputeBackFill {
+				rows = slices.Clone(rows)
+				slices.Reverse(rows)
+			}
+			last := -1
+			for _, i := range rows {
+				if !s.IsNull(i) {
+					last = i
+				} else if last >= 0 {
+					data.set(i, s.Value(last))
+				}
+			}
+		}
+	}
+	return &Series{name: s.name, dtype: dtype, data: data}, nil
 }
@@ -0,0 +1,432 @@
+package dataframe
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// NDJSONOptions configures ReadNDJSON and WriteNDJSON. Types fixes the type of the named columns;
+// the others are inferred from their values in the first InferRows lines, which defaults to
+// DefaultInferRows, while a negative InferRows examines every line. SkipMalformed makes
+// ReadNDJSON skip malformed lines and report them instead of failing on the first one. NaN and
+// TimeLayout are applied as in JSONOptions.
+type NDJSONOptions struct {
+	InferRows     int
+	Types         map[string]DType
+	SkipMalformed bool
+	NaN           NaNPolicy
+	TimeLayout    string
+}
+
+// NDJSONError reports a malformed NDJSON line, with its line number and, when known, the column
+// of the field that could not be read
+type NDJSONError struct {
+	Line   int
+	Column string
+	Err    error
+}
+
+// Error returns a description of the malformed line
+func (e *NDJSONError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("line %d, column '%s': %v", e.Line, e.Column, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *NDJSONError) Unwrap() error {
+	return e.Err
+}
+
+// NDJSONReport describes what ReadNDJSON read: the number of rows and the malformed lines skipped
+type NDJSONReport struct {
+	Rows    int
+	Skipped []*NDJSONError
+}
+
+// ReadNDJSON reads a DataFrame from newline-delimited JSON holding an object per line, one line
+// at a time. Blank lines are ignored. Columns appear in the order their fields are first seen, and
+// a field first seen after the inferred lines adds a column that is missing in earlier rows. Text
+// columns, and columns whose inferred values mix types, are String columns that hold other values
+// as their JSON text, as they do nested objects and arrays. An Int64 column whose later values hold
+// fractions becomes a Float64 column, and a column of missing values takes the type of its first
+// value; any other value that does not fit its column makes its line malformed.
+func ReadNDJSON(r io.Reader, opts NDJSONOptions) (*DataFrame, NDJSONReport, error) {
+	if opts.TimeLayout == "" {
+		opts.TimeLayout = time.RFC3339Nano
+	}
+	inferRows := opts.InferRows
+	if inferRows == 0 {
+		inferRows = DefaultInferRows
+	}
+
+	nr := &ndjsonReader{opts: opts, index: make(map[string]int)}
+	var report NDJSONReport
+	malformed := func(err error) error {
+		var lineErr *NDJSONError
+		if !opts.SkipMalformed || !errors.As(err, &lineErr) {
+			return err
+		}
+		report.Skipped = append(report.Skipped, lineErr)
+		return nil
+	}
+
+	var sample []ndjsonLine
+	settled := false
+	reader := bufio.NewReader(r)
+	for number := 1; ; number++ {
+		text, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, report, err
+		}
+		if trimmed := bytes.TrimSpace(text); len(trimmed) > 0 {
+			fields, parseErr := parseNDJSONLine(trimmed)
+			switch {
+			case parseErr != nil:
+				parseErr = malformed(&NDJSONError{Line: number, Err: parseErr})
+			case !settled && (inferRows < 0 || len(sample) < inferRows):
+				sample = append(sample, ndjsonLine{fields, number})
+			default:
+				if !settled {
+					if parseErr = nr.inferAndAppend(sample, malformed); parseErr != nil {
+						return nil, report, parseErr
+					}
+					settled = true
+				}
+				parseErr = malformed(nr.appendLine(fields, number))
+			}
+			if parseErr != nil {
+				return nil, report, parseErr
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	if !settled {
+		if err := nr.inferAndAppend(sample, malformed); err != nil {
+			return nil, report, err
+		}
+	}
+
+	for columnName := range opts.Types {
+		if _, ok := nr.index[columnName]; !ok {
+			return nil, report, fmt.Errorf("column '%s' does not exist", columnName)
+		}
+	}
+	series := make([]*Series, len(nr.names))
+	for c, name := range nr.names {
+		series[c] = &Series{name: name, dtype: nr.types[c], data: nr.columns[c]}
+	}
+	slices.SortStableFunc(report.Skipped, func(a, b *NDJSONError) int {
+		return cmp.Compare(a.Line, b.Line)
+	})
+	report.Rows = nr.rows
+	return frameOf(series), report, nil
+}
+
+// ndjsonField is a field of an NDJSON line: its key and its value, which is nil, a json.Number,
+// a string, a bool or the JSON text of a nested object or array
+type ndjsonField struct {
+	key   string
+	value interface{}
+}
+
+// ndjsonNested holds the JSON text of a nested object or array
+type ndjsonNested string
+
+// ndjsonLine holds the fields of a line read ahead to infer column types, with its line number
+type ndjsonLine struct {
+	fields []ndjsonField
+	number int
+}
+
+// ndjsonReader accumulates the columns read from NDJSON lines
+type ndjsonReader struct {
+	opts    NDJSONOptions
+	names   []string
+	index   map[string]int
+	types   []DType
+	fixed   []bool
+	columns []column
+	rows    int
+}
+
+// parseNDJSONLine decodes a line holding a JSON object into its fields, keeping the last of
+// repeated keys
+func parseNDJSONLine(text []byte) ([]ndjsonField, error) {
+	decoder := json.NewDecoder(bytes.NewReader(text))
+	decoder.UseNumber()
+	if err := expectJSONDelim(decoder, '{'); err != nil {
+		return nil, err
+	}
+
+	var fields []ndjsonField
+	seen := make(map[string]int)
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, err
+		}
+
+		var value interface{}
+		switch raw[0] {
+		case '{', '[':
+			var compact bytes.Buffer
+			json.Compact(&compact, raw)
+			value = ndjsonNested(compact.String())
+		default:
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return nil, err
+			}
+			if _, ok := value.(float64); ok {
+				value = json.Number(raw)
+			}
+		}
+
+		key := token.(string)
+		if i, ok := seen[key]; ok {
+			fields[i].value = value
+			continue
+		}
+		seen[key] = len(fields)
+		fields = append(fields, ndjsonField{key, value})
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after JSON object")
+	}
+	return fields, nil
+}
+
+// inferAndAppend settles the types of the columns seen in the sampled lines and appends the lines
+func (nr *ndjsonReader) inferAndAppend(sample []ndjsonLine, malformed func(error) error) error {
+	values := make(map[string][]interface{})
+	var order []string
+	for _, line := range sample {
+		for _, field := range line.fields {
+			if _, ok := values[field.key]; !ok {
+				order = append(order, field.key)
+			}
+			values[field.key] = append(values[field.key], field.value)
+		}
+	}
+	for _, key := range order {
+		dtype, fixed := nr.opts.Types[key]
+		if !fixed {
+			dtype = inferNDJSONType(values[key])
+		}
+		nr.addColumn(key, dtype, fixed)
+	}
+
+	for _, line := range sample {
+		if err := malformed(nr.appendLine(line.fields, line.number)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inferNDJSONType returns the type of a column holding the given values, falling back to String
+// when they mix types. The strings NaNAsString writes count as floats in columns of numbers.
+func inferNDJSONType(values []interface{}) DType {
+	inferred, nonFinite := Null, false
+	for _, value := range values {
+		if s, ok := value.(string); ok {
+			if _, ok := parseNonFinite(s); ok {
+				nonFinite = true
+				continue
+			}
+		}
+		t := ndjsonType(value)
+		switch {
+		case t == Null:
+		case inferred == Null || inferred == t:
+			inferred = t
+		case inferred.IsNumeric() && t.IsNumeric():
+			inferred = Float64
+		default:
+			return String
+		}
+	}
+	if nonFinite {
+		if inferred == Null || inferred.IsNumeric() {
+			return Float64
+		}
+		return String
+	}
+	return inferred
+}
+
+// ndjsonType returns the type a single field value infers as
+func ndjsonType(value interface{}) DType {
+	switch v := value.(type) {
+	case json.Number:
+		if _, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			return Int64
+		}
+		return Float64
+	case string, ndjsonNested:
+		return String
+	case bool:
+		return Bool
+	}
+	return Null
+}
+
+// addColumn adds a column of the given type, missing in every row read so far
+func (nr *ndjsonReader) addColumn(name string, dtype DType, fixed bool) {
+	col := newColumn(dtype, nr.rows)
+	for i := 0; i < nr.rows; i++ {
+		col.appendNull()
+	}
+	nr.index[name] = len(nr.names)
+	nr.names = append(nr.names, name)
+	nr.types = append(nr.types, dtype)
+	nr.fixed = append(nr.fixed, fixed)
+	nr.columns = append(nr.columns, col)
+}
+
+// appendLine appends the fields of a line as a row. Every field is converted before anything
+// changes, so that a malformed line leaves the columns as they were.
+func (nr *ndjsonReader) appendLine(fields []ndjsonField, number int) error {
+	converted := make([]interface{}, len(fields))
+	types := make([]DType, len(fields))
+	for f, field := range fields {
+		dtype, fixed := nr.opts.Types[field.key]
+		if c, ok := nr.index[field.key]; ok {
+			dtype, fixed = nr.types[c], nr.fixed[c]
+		} else if !fixed {
+			dtype = ndjsonType(field.value)
+		}
+		if dtype == Null && !fixed {
+			dtype = ndjsonType(field.value)
+		}
+
+		value, ok := nr.convert(field.value, dtype)
+		if !ok && dtype == Int64 && !fixed {
+			if value, ok = nr.convert(field.value, Float64); ok {
+				dtype = Float64
+			}
+		}
+		if !ok {
+			return &NDJSONError{Line: number, Column: field.key, Err: fmt.Errorf("cannot store %v as %s", field.value, dtype)}
+		}
+		converted[f], types[f] = value, dtype
+	}
+
+	present := make([]bool, len(nr.names), len(nr.names)+len(fields))
+	for f, field := range fields {
+		c, ok := nr.index[field.key]
+		if !ok {
+			_, fixed := nr.opts.Types[field.key]
+			nr.addColumn(field.key, types[f], fixed)
+			c = len(nr.names) - 1
+			present = append(present, false)
+		}
+		switch {
+		case nr.types[c] == types[f]:
+		case types[f] == Float64 && nr.types[c] == Int64:
+			nr.columns[c] = (&Series{dtype: Int64, data: nr.columns[c]}).asFloat64().data
+		default:
+			retyped := newColumn(types[f], nr.rows+1)
+			for i := 0; i < nr.rows; i++ {
+				retyped.appendNull()
+			}
+			nr.columns[c] = retyped
+		}
+		nr.types[c] = types[f]
+		nr.columns[c].appendValue(converted[f])
+		present[c] = true
+	}
+	for c, ok := range present {
+		if !ok {
+			nr.columns[c].appendNull()
+		}
+	}
+	nr.rows++
+	return nil
+}
+
+// convert returns a field value as a value of the given type
+func (nr *ndjsonReader) convert(value interface{}, dtype DType) (interface{}, bool) {
+	if value == nil {
+		return nil, true
+	}
+	switch dtype {
+	case Int64:
+		if v, ok := value.(json.Number); ok {
+			return toJSONResult(v.Int64())
+		}
+	case Float64:
+		switch v := value.(type) {
+		case json.Number:
+			return toJSONResult(v.Float64())
+		case string:
+			return parseNonFinite(v)
+		}
+	case Bool:
+		v, ok := value.(bool)
+		return v, ok
+	case Datetime:
+		if v, ok := value.(string); ok {
+			return toJSONResult(time.Parse(nr.opts.TimeLayout, v))
+		}
+	case String, Categorical:
+		switch v := value.(type) {
+		case string:
+			return v, true
+		case ndjsonNested:
+			return string(v), true
+		case json.Number:
+			return v.String(), true
+		case bool:
+			return strconv.FormatBool(v), true
+		}
+	}
+	return nil, false
+}
+
+// WriteNDJSON writes the DataFrame to w as newline-delimited JSON, one object per row written as
+// soon as it is built. Missing values are written as null.
+func (df *DataFrame) WriteNDJSON(w io.Writer, opts NDJSONOptions) error {
+	jsonOpts := JSONOptions{NaN: opts.NaN, TimeLayout: opts.TimeLayout}
+	if jsonOpts.TimeLayout == "" {
+		jsonOpts.TimeLayout = time.RFC3339Nano
+	}
+
+	writer := bufio.NewWriter(w)
+	var buf []byte
+	var err error
+	for i := 0; i < df.RowCount(); i++ {
+		buf = append(buf[:0], '{')
+		for c, s := range df.columns {
+			if c > 0 {
+				buf = append(buf, ',')
+			}
+			buf = append(appendJSONString(buf, s.name), ':')
+			if buf, err = appendJSONValue(buf, s, i, jsonOpts); err != nil {
+				return err
+			}
+		}
+		if _, err := writer.Write(append(buf, '}', '\n')); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
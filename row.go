@@ -0,0 +1,197 @@
+package dataframe
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+)
+
+// ErrNullValue is returned when a typed accessor reads a missing value
+var ErrNullValue = errors.New("value is null")
+
+// Row is a read-only view of a single row of a DataFrame
+type Row struct {
+	df    *DataFrame
+	index int
+}
+
+// AppendRow returns a new DataFrame with a single row appended, given as a map from column name
+// to value; columns absent from the map receive a missing value
+func (df *DataFrame) AppendRow(row map[string]interface{}) (*DataFrame, error) {
+	return df.AppendRows([]map[string]interface{}{row})
+}
+
+// AppendRows returns a new DataFrame with rows appended, given as maps from column name to value.
+// Columns with no type yet take the type inferred from the appended values. Appending to the most
+// recently grown DataFrame reuses its storage, so building a frame row by row takes amortized
+// constant time per row.
+func (df *DataFrame) AppendRows(rows []map[string]interface{}) (*DataFrame, error) {
+	if len(rows) == 0 {
+		return df, nil
+	}
+
+	for i, row := range rows {
+		for columnName := range row {
+			if _, ok := df.index[columnName]; !ok {
+				return nil, fmt.Errorf("row %d: column '%s' does not exist", i, columnName)
+			}
+		}
+	}
+
+	// Resolve the type of every column and check each value against it before touching the data
+	values := make([][]interface{}, len(df.columns))
+	types := make([]DType, len(df.columns))
+	for c, s := range df.columns {
+		values[c] = make([]interface{}, len(rows))
+		for i, row := range rows {
+			values[c][i] = row[s.name]
+		}
+
+		types[c] = s.dtype
+		if s.dtype == Null {
+			dtype, err := InferDType(values[c], 0)
+			if err != nil {
+				var mismatch *TypeMismatchError
+				if errors.As(err, &mismatch) {
+					mismatch.Column = s.name
+					return nil, mismatch
+				}
+				return nil, fmt.Errorf("column '%s': %w", s.name, err)
+			}
+			types[c] = dtype
+		}
+
+		for i, value := range values[c] {
+			if value != nil && !convertible(types[c], value) {
+				return nil, &TypeMismatchError{Column: s.name, Row: df.RowCount() + i, Expected: types[c], Value: value}
+			}
+		}
+	}
+
+	// Append through fresh views so that this DataFrame and others sharing its columns are unaffected
+	columns := make([]*Series, len(df.columns))
+	for c, s := range df.columns {
+		var data column
+		if types[c] != s.dtype {
+			data = newColumn(types[c], s.Len()+len(rows))
+			for i := 0; i < s.Len(); i++ {
+				data.appendNull()
+			}
+		} else {
+			data = s.data.view()
+		}
+		for _, value := range values[c] {
+			data.appendValue(value)
+		}
+		columns[c] = &Series{name: s.name, dtype: types[c], data: data}
+	}
+
+	return frameOf(columns), nil
+}
+
+// convertible reports whether a non-nil value can be stored in a column of the given type
+func convertible(dtype DType, x interface{}) bool {
+	switch dtype {
+	case Int64:
+		return isInteger(x)
+	case Float64:
+		_, ok := toFloat64(x)
+		return ok
+	case String, Categorical:
+		_, ok := x.(string)
+		return ok
+	case Bool:
+		_, ok := x.(bool)
+		return ok
+	case Datetime:
+		_, ok := x.(time.Time)
+		return ok
+	}
+	return false
+}
+
+// Row returns a view of the row at index i
+func (df *DataFrame) Row(i int) (Row, error) {
+	if i < 0 || i >= df.RowCount() {
+		return Row{}, fmt.Errorf("row index %d out of range", i)
+	}
+	return Row{df: df, index: i}, nil
+}
+
+// Rows returns an iterator over the index and view of every row in the DataFrame
+func (df *DataFrame) Rows() iter.Seq2[int, Row] {
+	return func(yield func(int, Row) bool) {
+		for i := 0; i < df.RowCount(); i++ {
+			if !yield(i, Row{df: df, index: i}) {
+				return
+			}
+		}
+	}
+}
+
+// Index returns the position of the row in its DataFrame
+func (r Row) Index() int {
+	return r.index
+}
+
+// Value returns the value of the given column, or nil when it is missing
+func (r Row) Value(column string) (interface{}, error) {
+	s, err := r.df.Column(column)
+	if err != nil {
+		return nil, err
+	}
+	return s.Value(r.index), nil
+}
+
+// IsNull reports whether the given column is missing in this row
+func (r Row) IsNull(column string) (bool, error) {
+	s, err := r.df.Column(column)
+	if err != nil {
+		return false, err
+	}
+	return s.IsNull(r.index), nil
+}
+
+// Values returns the values of the row in column order
+func (r Row) Values() []interface{} {
+	values := make([]interface{}, len(r.df.columns))
+	for i, s := range r.df.columns {
+		values[i] = s.Value(r.index)
+	}
+	return values
+}
+
+// Map returns the values of the row keyed by column name
+func (r Row) Map() map[string]interface{} {
+	values := make(map[string]interface{}, len(r.df.columns))
+	for _, s := range r.df.columns {
+		values[s.name] = s.Value(r.index)
+	}
+	return values
+}
+
+// Int64 returns the value of an Int64 column
+func (r Row) Int64(column string) (int64, error) {
+	return At[int64](r.df, r.index, column)
+}
+
+// Float64 returns the value of a Float64 column
+func (r Row) Float64(column string) (float64, error) {
+	return At[float64](r.df, r.index, column)
+}
+
+// String returns the value of a String or Categorical column
+func (r Row) String(column string) (string, error) {
+	return At[string](r.df, r.index, column)
+}
+
+// Bool returns the value of a Bool column
+func (r Row) Bool(column string) (bool, error) {
+	return At[bool](r.df, r.index, column)
+}
+
+// Time returns the value of a Datetime column
+func (r Row) Time(column string) (time.Time, error) {
+	return At[time.Time](r.df, r.index, column)
+}
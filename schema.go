@@ -0,0 +1,82 @@
+package dataframe
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Field describes a single column of a Schema
+type Field struct {
+	Name string
+	Type DType
+}
+
+// Schema describes the ordered columns of a DataFrame and their types
+type Schema []Field
+
+// Names returns the column names of the Schema in order
+func (s Schema) Names() []string {
+	names := make([]string, len(s))
+	for i, field := range s {
+		names[i] = field.Name
+	}
+	return names
+}
+
+// Field returns the field with the given name
+func (s Schema) Field(name string) (Field, bool) {
+	for _, field := range s {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
+
+// Validate checks that the Schema has at least one field and no duplicate names
+func (s Schema) Validate() error {
+	if len(s) == 0 {
+		return errors.New("schema has no fields")
+	}
+
+	seen := make(map[string]bool, len(s))
+	for _, field := range s {
+		if seen[field.Name] {
+			return fmt.Errorf("column name '%s' already exists", field.Name)
+		}
+		seen[field.Name] = true
+	}
+	return nil
+}
+
+// String returns the Schema as a list of name:type pairs
+func (s Schema) String() string {
+	parts := make([]string, len(s))
+	for i, field := range s {
+		parts[i] = field.Name + ":" + field.Type.String()
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// NewDataFrameWithSchema creates an empty DataFrame whose columns have the types declared by the schema
+func NewDataFrameWithSchema(schema Schema) (*DataFrame, error) {
+	if err := schema.Validate(); err != nil {
+		return nil, err
+	}
+
+	df := &DataFrame{index: make(map[string]int, len(schema))}
+	for _, field := range schema {
+		df.appendSeries(&Series{name: field.Name, dtype: field.Type, data: newColumn(field.Type, 0)})
+	}
+	return df, nil
+}
+
+// Schema returns the names and types of the columns in the DataFrame
+func (df *DataFrame) Schema() Schema {
+	schema := make(Schema, len(df.columns))
+	for i, s := range df.columns {
+		schema[i] = Field{Name: s.name, Type: s.dtype}
+	}
+	return schema
+}
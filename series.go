@@ -0,0 +1,458 @@
+package dataframe
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+// null is the element type of a column whose values are all missing and whose type is not yet known
+type null struct{}
+
+// column is the typed storage behind a Series
+type column interface {
+	Len() int
+	IsNull(i int) bool
+	Value(i int) interface{}
+	NullCount() int
+	Take(indices []int) column
+	Clone() column
+	view() column
+	fillNull(x interface{}) (column, bool)
+	set(i int, x interface{}) bool
+	appendValue(x interface{}) bool
+	appendNull()
+}
+
+// vector is a column backed by a concrete slice plus a validity bitmap. Several vectors may view
+// the same backing arrays with different lengths; end is shared between them and records how many
+// rows have been written, so only a vector reaching that far may append in place.
+type vector[T any] struct {
+	data  []T
+	valid bitmap
+	end   *atomic.Int64
+}
+
+// makeVector wraps a slice and its validity bitmap in a vector that owns them
+func makeVector[T any](data []T, valid bitmap) *vector[T] {
+	end := new(atomic.Int64)
+	end.Store(int64(len(data)))
+	return &vector[T]{data: data, valid: valid, end: end}
+}
+
+// newVector wraps a slice in a vector with every value marked valid
+func newVector[T any](data []T) *vector[T] {
+	return makeVector(data, newBitmap(len(data), true))
+}
+
+// Len returns the number of values in the vector
+func (v *vector[T]) Len() int {
+	return len(v.data)
+}
+
+// IsNull reports whether the value at row i is missing
+func (v *vector[T]) IsNull(i int) bool {
+	return !v.valid.get(i)
+}
+
+// Value returns the value at row i, or nil when it is missing
+func (v *vector[T]) Value(i int) interface{} {
+	if !v.valid.get(i) {
+		return nil
+	}
+	return v.data[i]
+}
+
+// NullCount returns the number of missing values in the vector
+func (v *vector[T]) NullCount() int {
+	return len(v.data) - v.valid.count(len(v.data))
+}
+
+// Take returns a new vector holding the rows at the given indices; a negative index yields a null
+func (v *vector[T]) Take(indices []int) column {
+	out := makeVector(make([]T, len(indices)), newBitmap(len(indices), false))
+	for i, idx := range indices {
+		if idx < 0 || !v.valid.get(idx) {
+			continue
+		}
+		out.data[i] = v.data[idx]
+		out.valid.set(i, true)
+	}
+	return out
+}
+
+// Clone returns a deep copy of the vector
+func (v *vector[T]) Clone() column {
+	data := make([]T, len(v.data))
+	copy(data, v.data)
+	return makeVector(data, v.valid.clone(len(v.data)))
+}
+
+// view returns a vector sharing the backing arrays, so that appending to it leaves v unchanged
+func (v *vector[T]) view() column {
+	return &vector[T]{data: v.data, valid: v.valid, end: v.end}
+}
+
+// fillNull returns a copy of the vector with missing values replaced by x, reporting false
+// when x cannot be stored as the element type
+func (v *vector[T]) fillNull(x interface{}) (column, bool) {
+	value, ok := convert[T](x)
+	if !ok {
+		return nil, false
+	}
+	out := makeVector(make([]T, len(v.data)), newBitmap(len(v.data), true))
+	for i := range v.data {
+		if v.valid.get(i) {
+			out.data[i] = v.data[i]
+		} else {
+			out.data[i] = value
+		}
+	}
+	return out, true
+}
+
+// set overwrites row i with x, or with a missing value when x is nil, reporting false on a type mismatch
+func (v *vector[T]) set(i int, x interface{}) bool {
+	if x == nil {
+		var zero T
+		v.data[i] = zero
+		v.valid.set(i, false)
+		return true
+	}
+	value, ok := convert[T](x)
+	if !ok {
+		return false
+	}
+	v.data[i] = value
+	v.valid.set(i, true)
+	return true
+}
+
+// appendValue converts x to the element type and appends it, reporting false on a type mismatch
+func (v *vector[T]) appendValue(x interface{}) bool {
+	if x == nil {
+		v.appendNull()
+		return true
+	}
+	value, ok := convert[T](x)
+	if !ok {
+		return false
+	}
+	v.push(value, true)
+	return true
+}
+
+// appendNull appends a missing value
+func (v *vector[T]) appendNull() {
+	var zero T
+	v.push(zero, false)
+}
+
+// push appends a value and its validity, moving to private copies of the backing arrays when
+// another vector has already written past the end of this one
+func (v *vector[T]) push(x T, valid bool) {
+	n := len(v.data)
+	if n == cap(v.data) || !v.end.CompareAndSwap(int64(n), int64(n+1)) {
+		v.detach(n + 1)
+		v.end.Store(int64(n + 1))
+	}
+	v.data = append(v.data, x)
+	v.valid = v.valid.grow(n + 1)
+	v.valid.setShared(n, valid)
+}
+
+// detach copies the vector onto backing arrays it alone owns, with room for at least capacity rows
+func (v *vector[T]) detach(capacity int) {
+	capacity = max(capacity, 2*len(v.data))
+	data := make([]T, len(v.data), capacity)
+	copy(data, v.data)
+	v.data, v.valid = data, v.valid.clone(capacity)
+	v.end = new(atomic.Int64)
+}
+
+// convert converts a boxed value to T, widening integer and float types where lossless
+func convert[T any](x interface{}) (T, bool) {
+	var (
+		out   T
+		value interface{}
+		ok    bool
+	)
+	switch any(out).(type) {
+	case int64:
+		value, ok = toInt64(x)
+	case float64:
+		value, ok = toFloat64(x)
+	case string:
+		value, ok = x.(string)
+	case bool:
+		value, ok = x.(bool)
+	case time.Time:
+		value, ok = x.(time.Time)
+	}
+	if !ok {
+		return out, false
+	}
+	return value.(T), true
+}
+
+// toInt64 converts any Go integer type to int64
+func toInt64(x interface{}) (int64, bool) {
+	switch v := x.(type) {
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint:
+		if uint64(v) > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	}
+	return 0, false
+}
+
+// toFloat64 converts any Go integer or float type to float64
+func toFloat64(x interface{}) (float64, bool) {
+	switch v := x.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	}
+	if i, ok := toInt64(x); ok {
+		return float64(i), true
+	}
+	return 0, false
+}
+
+// isInteger reports whether x holds a Go integer type
+func isInteger(x interface{}) bool {
+	_, ok := toInt64(x)
+	return ok
+}
+
+// isFloat reports whether x holds a Go float type
+func isFloat(x interface{}) bool {
+	switch x.(type) {
+	case float32, float64:
+		return true
+	}
+	return false
+}
+
+// nullColumn returns a column of n missing values with no element type
+func nullColumn(n int) column {
+	return makeVector(make([]null, n), newBitmap(n, false))
+}
+
+// Series represents a named column of typed values
+type Series struct {
+	name  string
+	dtype DType
+	data  column
+}
+
+// NewSeries creates a Series from boxed values, inferring its type from the first DefaultInferRows rows
+func NewSeries(name string, values []interface{}) (*Series, error) {
+	dtype, err := InferDType(values, DefaultInferRows)
+	if err != nil {
+		var mismatch *TypeMismatchError
+		if errors.As(err, &mismatch) {
+			mismatch.Column = name
+			return nil, mismatch
+		}
+		return nil, fmt.Errorf("column '%s': %w", name, err)
+	}
+	return NewTypedSeries(name, dtype, values)
+}
+
+// NewTypedSeries creates a Series of the given type from boxed values, returning a
+// *TypeMismatchError for the first value that cannot be stored as that type
+func NewTypedSeries(name string, dtype DType, values []interface{}) (*Series, error) {
+	col := newColumn(dtype, len(values))
+	for i, value := range values {
+		if !col.appendValue(value) {
+			return nil, &TypeMismatchError{Column: name, Row: i, Expected: dtype, Value: value}
+		}
+	}
+	return &Series{name: name, dtype: dtype, data: col}, nil
+}
+
+// NewInt64Series creates a Series backed by a copy of the given int64 values
+func NewInt64Series(name string, values []int64) *Series {
+	return &Series{name: name, dtype: Int64, data: newVector(slices.Clone(values))}
+}
+
+// NewFloat64Series creates a Series backed by a copy of the given float64 values
+func NewFloat64Series(name string, values []float64) *Series {
+	return &Series{name: name, dtype: Float64, data: newVector(slices.Clone(values))}
+}
+
+// NewStringSeries creates a Series backed by a copy of the given string values
+func NewStringSeries(name string, values []string) *Series {
+	return &Series{name: name, dtype: String, data: newVector(slices.Clone(values))}
+}
+
+// NewBoolSeries creates a Series backed by a copy of the given bool values
+func NewBoolSeries(name string, values []bool) *Series {
+	return &Series{name: name, dtype: Bool, data: newVector(slices.Clone(values))}
+}
+
+// NewTimeSeries creates a Series backed by a copy of the given time values
+func NewTimeSeries(name string, values []time.Time) *Series {
+	return &Series{name: name, dtype: Datetime, data: newVector(slices.Clone(values))}
+}
+
+// Name returns the name of the Series
+func (s *Series) Name() string {
+	return s.name
+}
+
+// DType returns the type of the values in the Series
+func (s *Series) DType() DType {
+	return s.dtype
+}
+
+// Len returns the number of values in the Series
+func (s *Series) Len() int {
+	return s.data.Len()
+}
+
+// IsNull reports whether the value at row i is missing
+func (s *Series) IsNull(i int) bool {
+	return s.data.IsNull(i)
+}
+
+// Value returns the value at row i, or nil when it is missing
+func (s *Series) Value(i int) interface{} {
+	return s.data.Value(i)
+}
+
+// Values returns a boxed copy of every value in the Series
+func (s *Series) Values() []interface{} {
+	values := make([]interface{}, s.Len())
+	for i := range values {
+		values[i] = s.data.Value(i)
+	}
+	return values
+}
+
+// NullCount returns the number of missing values in the Series
+func (s *Series) NullCount() int {
+	return s.data.NullCount()
+}
+
+// rename returns a Series sharing the same storage under a different name
+func (s *Series) rename(name string) *Series {
+	return &Series{name: name, dtype: s.dtype, data: s.data}
+}
+
+// take returns a new Series holding the rows at the given indices
+func (s *Series) take(indices []int) *Series {
+	return &Series{name: s.name, dtype: s.dtype, data: s.data.Take(indices)}
+}
+
+// concatSeries joins Series end to end into a new named Series; Series with no type yet take the
+// type of the others, and integers joined with floats become floats
+func concatSeries(name string, parts []*Series) (*Series, error) {
+	dtype := Null
+	total := 0
+	for _, s := range parts {
+		total += s.Len()
+		switch {
+		case s.dtype == Null:
+		case dtype == Null:
+			dtype = s.dtype
+		case dtype != s.dtype && dtype.IsNumeric() && s.dtype.IsNumeric():
+			dtype = Float64
+		case dtype != s.dtype:
+			return nil, fmt.Errorf("column '%s' mixes %s and %s values", name, dtype, s.dtype)
+		}
+	}
+
+	data := newColumn(dtype, total)
+	for _, s := range parts {
+		for i := 0; i < s.Len(); i++ {
+			if s.IsNull(i) {
+				data.appendNull()
+			} else {
+				data.appendValue(s.Value(i))
+			}
+		}
+	}
+	return &Series{name: name, dtype: dtype, data: data}, nil
+}
+
+// float64s returns the values of a numeric Series as float64s alongside its validity bitmap;
+// ok is false when the Series is not numeric
+func (s *Series) float64s() (values []float64, valid bitmap, ok bool) {
+	switch c := s.data.(type) {
+	case *vector[float64]:
+		return c.data, c.valid, true
+	case *vector[int64]:
+		values = make([]float64, len(c.data))
+		for i, v := range c.data {
+			values[i] = float64(v)
+		}
+		return values, c.valid, true
+	}
+	return nil, nil, false
+}
+
+// asFloat64 returns a numeric Series converted to Float64
+func (s *Series) asFloat64() *Series {
+	if s.dtype == Float64 {
+		return s
+	}
+	values, valid, _ := s.float64s()
+	return &Series{name: s.name, dtype: Float64, data: makeVector(values, valid)}
+}
+
+// comparator returns a function ordering two rows of the Series that both hold values
+func (s *Series) comparator() func(i, j int) int {
+	switch c := s.data.(type) {
+	case *vector[int64]:
+		return func(i, j int) int { return cmp.Compare(c.data[i], c.data[j]) }
+	case *vector[float64]:
+		return func(i, j int) int { return cmp.Compare(c.data[i], c.data[j]) }
+	case *vector[string]:
+		return func(i, j int) int { return cmp.Compare(c.data[i], c.data[j]) }
+	case *vector[bool]:
+		return func(i, j int) int { return compareBool(c.data[i], c.data[j]) }
+	case *vector[time.Time]:
+		return func(i, j int) int { return c.data[i].Compare(c.data[j]) }
+	}
+	return func(i, j int) int { return 0 }
+}
+
+// compareBool orders false before true
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case !a:
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,146 @@
+package dataframe
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// rowValues returns the values of every row of a DataFrame
+func rowValues(t *testing.T, df *DataFrame) [][]interface{} {
+	t.Helper()
+	rows := make([][]interface{}, df.RowCount())
+	for i := range rows {
+		row, err := df.Row(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows[i] = row.Values()
+	}
+	return rows
+}
+
+// appendRow appends a row, failing the test on an error
+func appendRow(t *testing.T, df *DataFrame, row map[string]interface{}) *DataFrame {
+	t.Helper()
+	out, err := df.AppendRow(row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// checkRows fails the test unless a DataFrame holds the given rows
+func checkRows(t *testing.T, name string, df *DataFrame, want [][]interface{}) {
+	t.Helper()
+	if got := rowValues(t, df); !reflect.DeepEqual(got, want) {
+		t.Errorf("%s holds %v, want %v", name, got, want)
+	}
+}
+
+// baseFrame returns a DataFrame of n rows built by appending, so that its columns have spare
+// capacity and later appends may share its storage; every third name is missing
+func baseFrame(t *testing.T, n int) (*DataFrame, [][]interface{}) {
+	t.Helper()
+	df, err := NewDataFrameWithSchema(Schema{{Name: "id", Type: Int64}, {Name: "name", Type: String}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rows [][]interface{}
+	for i := 0; i < n; i++ {
+		var name interface{} = fmt.Sprintf("row %d", i)
+		if i%3 == 0 {
+			name = nil
+		}
+		df = appendRow(t, df, map[string]interface{}{"id": int64(i), "name": name})
+		rows = append(rows, []interface{}{int64(i), name})
+	}
+	return df, rows
+}
+
+// with returns rows followed by one more row, without modifying rows
+func with(rows [][]interface{}, row ...interface{}) [][]interface{} {
+	return append(append([][]interface{}{}, rows...), row)
+}
+
+func TestAppendRowFromSameParent(t *testing.T) {
+	// 63 and 64 rows put the appended rows at the end of a bitmap word and at the start of a new one
+	for _, n := range []int{3, 63, 64} {
+		t.Run(fmt.Sprintf("rows=%d", n), func(t *testing.T) {
+			base, rows := baseFrame(t, n)
+
+			first := appendRow(t, base, map[string]interface{}{"id": int64(100), "name": nil})
+			second := appendRow(t, base, map[string]interface{}{"id": int64(200), "name": "valid"})
+			checkRows(t, "base", base, rows)
+			checkRows(t, "first", first, with(rows, int64(100), nil))
+			checkRows(t, "second", second, with(rows, int64(200), "valid"))
+
+			firstAgain := appendRow(t, first, map[string]interface{}{"id": int64(101), "name": "after null"})
+			secondAgain := appendRow(t, second, map[string]interface{}{"id": int64(201), "name": nil})
+			checkRows(t, "base", base, rows)
+			checkRows(t, "first", first, with(rows, int64(100), nil))
+			checkRows(t, "second", second, with(rows, int64(200), "valid"))
+			checkRows(t, "first appended again", firstAgain, with(with(rows, int64(100), nil), int64(101), "after null"))
+			checkRows(t, "second appended again", secondAgain, with(with(rows, int64(200), "valid"), int64(201), nil))
+		})
+	}
+}
+
+func TestAppendRowAfterSelectAndTake(t *testing.T) {
+	base, rows := baseFrame(t, 5)
+
+	selected, err := base.Select("name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	taken := base.take([]int{4, 1})
+
+	fromSelected := appendRow(t, selected, map[string]interface{}{"name": "selected"})
+	fromTaken := appendRow(t, taken, map[string]interface{}{"id": int64(300), "name": nil})
+	fromBase := appendRow(t, base, map[string]interface{}{"id": int64(400), "name": "base"})
+
+	names := make([][]interface{}, len(rows))
+	for i, row := range rows {
+		names[i] = row[1:]
+	}
+	checkRows(t, "base", base, rows)
+	checkRows(t, "selected", selected, names)
+	checkRows(t, "taken", taken, [][]interface{}{rows[4], rows[1]})
+	checkRows(t, "appended to selected", fromSelected, with(names, "selected"))
+	checkRows(t, "appended to taken", fromTaken, [][]interface{}{rows[4], rows[1], {int64(300), nil}})
+	checkRows(t, "appended to base", fromBase, with(rows, int64(400), "base"))
+}
+
+func TestAppendRowConcurrently(t *testing.T) {
+	base, rows := baseFrame(t, 10)
+
+	const writers = 8
+	results := make([]*DataFrame, writers)
+	errs := make([]error, writers)
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			var name interface{} = fmt.Sprintf("writer %d", w)
+			if w%2 == 0 {
+				name = nil
+			}
+			results[w], errs[w] = base.AppendRow(map[string]interface{}{"id": int64(w), "name": name})
+		}(w)
+	}
+	wg.Wait()
+
+	checkRows(t, "base", base, rows)
+	for w, result := range results {
+		if errs[w] != nil {
+			t.Fatal(errs[w])
+		}
+		var name interface{} = fmt.Sprintf("writer %d", w)
+		if w%2 == 0 {
+			name = nil
+		}
+		checkRows(t, fmt.Sprintf("result of writer %d", w), result, with(rows, int64(w), name))
+	}
+}
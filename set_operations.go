@@ -0,0 +1,197 @@
+package dataframe
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Axis selects the direction in which Concat combines DataFrames
+type Axis int
+
+const (
+	// AxisRows stacks DataFrames on top of each other, aligning columns by name
+	AxisRows Axis = iota
+	// AxisColumns places DataFrames side by side, aligning rows by position
+	AxisColumns
+)
+
+// Concat combines DataFrames along an axis. Stacking rows with OuterJoin keeps every column, in
+// order of first appearance, and fills columns a DataFrame lacks with missing values; InnerJoin
+// keeps only the columns all DataFrames share, in the order of the first. Placing columns side by
+// side with OuterJoin pads shorter DataFrames with missing values; InnerJoin cuts longer ones to
+// the length of the shortest. Integer columns stacked with float columns become float columns.
+func Concat(frames []*DataFrame, axis Axis, join JoinHow) (*DataFrame, error) {
+	if len(frames) == 0 {
+		return nil, errors.New("no DataFrames provided for concatenation")
+	}
+	if join != InnerJoin && join != OuterJoin {
+		return nil, fmt.Errorf("concatenation supports inner and outer joins, not join type %d", join)
+	}
+
+	switch axis {
+	case AxisRows:
+		return concatRows(frames, join)
+	case AxisColumns:
+		return concatColumns(frames, join)
+	}
+	return nil, fmt.Errorf("unknown axis %d", axis)
+}
+
+// concatRows stacks DataFrames, aligning their columns by name
+func concatRows(frames []*DataFrame, join JoinHow) (*DataFrame, error) {
+	var names []string
+	if join == InnerJoin {
+		for _, name := range frames[0].ColumnNames() {
+			shared := true
+			for _, frame := range frames[1:] {
+				if _, ok := frame.index[name]; !ok {
+					shared = false
+					break
+				}
+			}
+			if shared {
+				names = append(names, name)
+			}
+		}
+	} else {
+		seen := make(map[string]bool)
+		for _, frame := range frames {
+			for _, s := range frame.columns {
+				if !seen[s.name] {
+					seen[s.name] = true
+					names = append(names, s.name)
+				}
+			}
+		}
+	}
+
+	columns := make([]*Series, len(names))
+	parts := make([]*Series, len(frames))
+	for i, name := range names {
+		for f, frame := range frames {
+			if j, ok := frame.index[name]; ok {
+				parts[f] = frame.columns[j]
+			} else {
+				parts[f] = &Series{name: name, dtype: Null, data: nullColumn(frame.RowCount())}
+			}
+		}
+		s, err := concatSeries(name, parts)
+		if err != nil {
+			return nil, err
+		}
+		columns[i] = s
+	}
+	return frameOf(columns), nil
+}
+
+// concatColumns places DataFrames side by side, aligning their rows by position
+func concatColumns(frames []*DataFrame, join JoinHow) (*DataFrame, error) {
+	rowCount := frames[0].RowCount()
+	for _, frame := range frames[1:] {
+		if join == InnerJoin {
+			rowCount = min(rowCount, frame.RowCount())
+		} else {
+			rowCount = max(rowCount, frame.RowCount())
+		}
+	}
+
+	columns := make([]*Series, 0)
+	seen := make(map[string]bool)
+	for _, frame := range frames {
+		rows := rowRange(rowCount)
+		for i := frame.RowCount(); i < rowCount; i++ {
+			rows[i] = -1
+		}
+
+		for _, s := range frame.columns {
+			if seen[s.name] {
+				return nil, fmt.Errorf("column name '%s' already exists", s.name)
+			}
+			seen[s.name] = true
+			if frame.RowCount() != rowCount {
+				s = s.take(rows)
+			}
+			columns = append(columns, s)
+		}
+	}
+	return frameOf(columns), nil
+}
+
+// Union returns the rows of this DataFrame followed by the rows of another with the same columns,
+// keeping duplicates
+func (df *DataFrame) Union(other *DataFrame) (*DataFrame, error) {
+	return df.stackWith(other)
+}
+
+// UnionDistinct returns the distinct rows of this DataFrame and another with the same columns, in
+// order of first appearance
+func (df *DataFrame) UnionDistinct(other *DataFrame) (*DataFrame, error) {
+	stacked, err := df.stackWith(other)
+	if err != nil {
+		return nil, err
+	}
+	return stacked.DropDuplicates(nil, KeepFirst)
+}
+
+// Intersect returns the distinct rows of this DataFrame that also appear in another with the same
+// columns, in order of first appearance
+func (df *DataFrame) Intersect(other *DataFrame) (*DataFrame, error) {
+	return df.filterByOther(other, true)
+}
+
+// Except returns the distinct rows of this DataFrame that do not appear in another with the same
+// columns, in order of first appearance
+func (df *DataFrame) Except(other *DataFrame) (*DataFrame, error) {
+	return df.filterByOther(other, false)
+}
+
+// filterByOther returns the distinct rows of this DataFrame whose presence in another DataFrame
+// with the same columns matches present. Rows are equal when every column holds equal values,
+// where missing values equal each other and so do NaNs.
+func (df *DataFrame) filterByOther(other *DataFrame, present bool) (*DataFrame, error) {
+	stacked, err := df.stackWith(other)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, size := rowCodes(stacked.columns)
+	n := df.RowCount()
+	inOther := make([]bool, size)
+	for _, code := range codes[n:] {
+		inOther[code] = true
+	}
+
+	rows := firstOccurrences(codes, size, n)
+	rows = slices.DeleteFunc(rows, func(i int) bool {
+		return inOther[codes[i]] != present
+	})
+	return df.take(rows), nil
+}
+
+// stackWith stacks the rows of another DataFrame with the same column names below this one,
+// reordering its columns to match
+func (df *DataFrame) stackWith(other *DataFrame) (*DataFrame, error) {
+	if len(df.columns) != len(other.columns) {
+		return nil, fmt.Errorf("DataFrames have different columns: %v and %v", df.ColumnNames(), other.ColumnNames())
+	}
+	for _, s := range df.columns {
+		if _, ok := other.index[s.name]; !ok {
+			return nil, fmt.Errorf("DataFrames have different columns: %v and %v", df.ColumnNames(), other.ColumnNames())
+		}
+	}
+	return concatRows([]*DataFrame{df, other}, InnerJoin)
+}
+
+// firstOccurrences returns the first row holding each code among the first n rows, in row order
+func firstOccurrences(codes []int, size, n int) []int {
+	seen := make([]bool, size)
+	rows := make([]int, 0, n)
+	for i, code := range codes[:n] {
+		if !seen[code] {
+			seen[code] = true
+			rows = append(rows, i)
+		}
+	}
+	return rows
+}
@@ -0,0 +1,366 @@
+package dataframe
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// structField describes how a struct field maps onto a DataFrame column
+type structField struct {
+	name      string
+	index     []int
+	dtype     DType
+	text      bool
+	omitEmpty bool
+}
+
+// FromStructs creates a DataFrame from a slice of structs or struct pointers. Each exported field
+// becomes a column named by its `df:"name,omitempty"` tag, or by the field name when untagged; a tag
+// of "-" skips the field. Fields of embedded structs are promoted to columns of their own. Nil
+// pointers become missing values, as do zero values of fields tagged omitempty. Fields implementing
+// encoding.TextMarshaler are stored as String columns.
+func FromStructs(slice interface{}) (*DataFrame, error) {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected a slice of structs, got %T", slice)
+	}
+
+	elemType := v.Type().Elem()
+	if elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a slice of structs, got %T", slice)
+	}
+
+	fields, err := structFields(elemType)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]column, len(fields))
+	for i, field := range fields {
+		columns[i] = newColumn(field.dtype, v.Len())
+	}
+
+	for row := 0; row < v.Len(); row++ {
+		item := v.Index(row)
+		for i, field := range fields {
+			value, err := readStructField(item, field)
+			if err != nil {
+				return nil, fmt.Errorf("row %d field '%s': %w", row, field.name, err)
+			}
+			if !columns[i].appendValue(value) {
+				return nil, &TypeMismatchError{Column: field.name, Row: row, Expected: field.dtype, Value: value}
+			}
+		}
+	}
+
+	series := make([]*Series, len(fields))
+	for i, field := range fields {
+		series[i] = &Series{name: field.name, dtype: field.dtype, data: columns[i]}
+	}
+	return frameOf(series), nil
+}
+
+// FromSlice creates a DataFrame from a typed slice of structs or struct pointers, following the rules of FromStructs
+func FromSlice[T any](items []T) (*DataFrame, error) {
+	return FromStructs(items)
+}
+
+// ToStructs fills the slice pointed to by dst with one struct per row, matching columns to fields
+// by the same naming rules as FromStructs. Missing values leave fields at their zero value or nil.
+// Columns without a matching field, and fields without a matching column, are ignored.
+func (df *DataFrame) ToStructs(dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("expected a pointer to a slice of structs, got %T", dst)
+	}
+
+	sliceValue := v.Elem()
+	elemType := sliceValue.Type().Elem()
+	isPointer := elemType.Kind() == reflect.Pointer
+	structType := elemType
+	if isPointer {
+		structType = elemType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("expected a pointer to a slice of structs, got %T", dst)
+	}
+
+	fields, err := structFields(structType)
+	if err != nil {
+		return err
+	}
+
+	type binding struct {
+		field  structField
+		series *Series
+	}
+	bindings := make([]binding, 0, len(fields))
+	for _, field := range fields {
+		if s, err := df.Column(field.name); err == nil {
+			bindings = append(bindings, binding{field: field, series: s})
+		}
+	}
+
+	out := reflect.MakeSlice(sliceValue.Type(), df.RowCount(), df.RowCount())
+	for row := 0; row < df.RowCount(); row++ {
+		item := reflect.New(structType).Elem()
+		for _, b := range bindings {
+			value := b.series.Value(row)
+			if value == nil {
+				continue
+			}
+			if err := writeStructField(item, b.field, value); err != nil {
+				return fmt.Errorf("row %d column '%s': %w", row, b.field.name, err)
+			}
+		}
+		if isPointer {
+			out.Index(row).Set(item.Addr())
+		} else {
+			out.Index(row).Set(item)
+		}
+	}
+
+	sliceValue.Set(out)
+	return nil
+}
+
+// structFields lists the column mappings of a struct type, promoting the fields of embedded structs
+func structFields(t reflect.Type) ([]structField, error) {
+	fields := make([]structField, 0, t.NumField())
+	if err := collectStructFields(t, nil, &fields); err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("struct type %s has no exported fields", t)
+	}
+
+	seen := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if seen[field.name] {
+			return nil, fmt.Errorf("column name '%s' already exists", field.name)
+		}
+		seen[field.name] = true
+	}
+	return fields, nil
+}
+
+// collectStructFields appends the column mappings of t, whose fields are reached through index, to fields
+func collectStructFields(t reflect.Type, index []int, fields *[]structField) error {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("df")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		fieldIndex := append(append([]int(nil), index...), i)
+		fieldType := f.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+
+		if f.Anonymous && name == "" && fieldType.Kind() == reflect.Struct && !isScalarStruct(fieldType) {
+			if !f.IsExported() && f.Type.Kind() == reflect.Pointer {
+				// Promoted fields behind an unexported pointer cannot be allocated when writing back
+				continue
+			}
+			if err := collectStructFields(fieldType, fieldIndex, fields); err != nil {
+				return err
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		dtype, text, ok := fieldDType(f.Type)
+		if !ok {
+			return fmt.Errorf("field '%s' has unsupported type %s", f.Name, f.Type)
+		}
+		*fields = append(*fields, structField{
+			name:      name,
+			index:     fieldIndex,
+			dtype:     dtype,
+			text:      text,
+			omitEmpty: options == "omitempty",
+		})
+	}
+	return nil
+}
+
+// isScalarStruct reports whether a struct type is stored as a single value rather than flattened
+func isScalarStruct(t reflect.Type) bool {
+	return t == timeType || reflect.PointerTo(t).Implements(textMarshalerType)
+}
+
+// fieldDType returns the column type for a field type and whether it is stored through encoding.TextMarshaler
+func fieldDType(t reflect.Type) (DType, bool, bool) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return Datetime, false, true
+	}
+	if reflect.PointerTo(t).Implements(textMarshalerType) {
+		return String, true, true
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Int64, false, true
+	case reflect.Float32, reflect.Float64:
+		return Float64, false, true
+	case reflect.String:
+		return String, false, true
+	case reflect.Bool:
+		return Bool, false, true
+	}
+	return Null, false, false
+}
+
+// readStructField returns the boxed column value of a field, or nil when it is missing
+func readStructField(item reflect.Value, field structField) (interface{}, error) {
+	v, ok := fieldByIndex(item, field.index, false)
+	if !ok {
+		return nil, nil
+	}
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if field.omitEmpty && v.IsZero() {
+		return nil, nil
+	}
+
+	if field.text {
+		addressable := reflect.New(v.Type())
+		addressable.Elem().Set(v)
+		text, err := addressable.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return string(text), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("value %d overflows int64", v.Uint())
+		}
+		return int64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return v.Bool(), nil
+	}
+	return v.Interface(), nil
+}
+
+// writeStructField stores a non-nil column value into a field, allocating pointers along the way
+func writeStructField(item reflect.Value, field structField, value interface{}) error {
+	v, _ := fieldByIndex(item, field.index, true)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+
+	if field.text {
+		text, ok := value.(string)
+		if !ok || !v.Addr().Type().Implements(textUnmarshalerType) {
+			return fmt.Errorf("cannot store %T in field of type %s", value, v.Type())
+		}
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text))
+	}
+
+	switch x := value.(type) {
+	case int64:
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v.OverflowInt(x) {
+				return fmt.Errorf("value %d overflows %s", x, v.Type())
+			}
+			v.SetInt(x)
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if x < 0 || v.OverflowUint(uint64(x)) {
+				return fmt.Errorf("value %d overflows %s", x, v.Type())
+			}
+			v.SetUint(uint64(x))
+			return nil
+		case reflect.Float32, reflect.Float64:
+			v.SetFloat(float64(x))
+			return nil
+		}
+	case float64:
+		if v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64 {
+			v.SetFloat(x)
+			return nil
+		}
+	case string:
+		if v.Kind() == reflect.String {
+			v.SetString(x)
+			return nil
+		}
+	case bool:
+		if v.Kind() == reflect.Bool {
+			v.SetBool(x)
+			return nil
+		}
+	case time.Time:
+		if v.Type() == timeType {
+			v.Set(reflect.ValueOf(x))
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot store %T in field of type %s", value, v.Type())
+}
+
+// fieldByIndex walks a field path through embedded struct pointers; when allocate is false a nil
+// pointer along the path reports false, otherwise it is allocated
+func fieldByIndex(v reflect.Value, index []int, allocate bool) (reflect.Value, bool) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !allocate {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
@@ -1,6 +1,220 @@
 package dataframe
 
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"time"
+)
+
 // Function to perform time series analysis on the DataFrame
 func (df *DataFrame) TimeSeriesAnalysis() {
 	// Implement your time series analysis logic here
 }
+
+// AsOfDirection selects which right rows MergeAsOf may pair with a left row
+type AsOfDirection int
+
+const (
+	// Backward pairs a left row with the last right row whose key is less than or equal to its key
+	Backward AsOfDirection = iota
+	// Forward pairs a left row with the first right row whose key is greater than or equal to its key
+	Forward
+	// Nearest pairs a left row with the closer of its backward and forward matches, preferring the
+	// backward one on a tie
+	Nearest
+)
+
+// AsOfOptions configures MergeAsOf. On names the numeric or Datetime key column present in both
+// DataFrames, and By names columns whose values must match exactly. Tolerance bounds the distance
+// between paired keys: a time.Duration for Datetime keys or a number for numeric keys, where nil
+// means no bound. Suffixes are applied as in Merge.
+type AsOfOptions struct {
+	On        string
+	By        []string
+	Direction AsOfDirection
+	Tolerance interface{}
+	Suffixes  [2]string
+}
+
+// MergeAsOf pairs every left row with the right row whose key is nearest in the chosen direction,
+// among right rows with equal By values; left rows without such a row get missing values. Both
+// DataFrames must be sorted in ascending order on the On column, which may hold missing values
+// that never match. The result holds the left columns followed by the right columns other than
+// On and By, in the order of the left DataFrame.
+func MergeAsOf(left, right *DataFrame, opts AsOfOptions) (*DataFrame, error) {
+	if opts.On == "" {
+		return nil, errors.New("as-of join column is required")
+	}
+	if opts.Direction != Backward && opts.Direction != Forward && opts.Direction != Nearest {
+		return nil, fmt.Errorf("unknown as-of direction %d", opts.Direction)
+	}
+	leftOn, err := left.Column(opts.On)
+	if err != nil {
+		return nil, err
+	}
+	rightOn, err := right.Column(opts.On)
+	if err != nil {
+		return nil, err
+	}
+
+	leftCodes := make([]int, left.RowCount())
+	rightCodes := make([]int, right.RowCount())
+	size := 1
+	if len(opts.By) > 0 {
+		leftBy, err := joinKeys(left, opts.By)
+		if err != nil {
+			return nil, err
+		}
+		rightBy, err := joinKeys(right, opts.By)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkJoinable(leftBy, rightBy); err != nil {
+			return nil, err
+		}
+		rightCodes, leftCodes, size = buildProbeCodes(rightBy, leftBy, false)
+	}
+
+	keys := asOfKeys{leftCodes: leftCodes, rightCodes: rightCodes, size: size, direction: opts.Direction}
+	var matches []int
+	switch {
+	case leftOn.dtype == Datetime && rightOn.dtype == Datetime:
+		var tolerance time.Duration
+		if opts.Tolerance != nil {
+			var ok bool
+			if tolerance, ok = opts.Tolerance.(time.Duration); !ok {
+				return nil, fmt.Errorf("tolerance for Datetime column '%s' must be a time.Duration, not %T", opts.On, opts.Tolerance)
+			}
+		}
+		matches, err = asOfMatch(keys, unixNanos(leftOn), unixNanos(rightOn), int64(tolerance), opts.Tolerance != nil)
+	case leftOn.dtype == Int64 && rightOn.dtype == Int64 && (opts.Tolerance == nil || isInteger(opts.Tolerance)):
+		tolerance, _ := toInt64(opts.Tolerance)
+		matches, err = asOfMatch(keys, leftOn.data.(*vector[int64]), rightOn.data.(*vector[int64]), tolerance, opts.Tolerance != nil)
+	case leftOn.dtype.IsNumeric() && rightOn.dtype.IsNumeric():
+		tolerance, ok := toFloat64(opts.Tolerance)
+		if opts.Tolerance != nil && !ok {
+			return nil, fmt.Errorf("tolerance for numeric column '%s' must be a number, not %T", opts.On, opts.Tolerance)
+		}
+		matches, err = asOfMatch(keys, leftOn.asFloat64().data.(*vector[float64]), rightOn.asFloat64().data.(*vector[float64]), tolerance, opts.Tolerance != nil)
+	default:
+		return nil, fmt.Errorf("as-of join column '%s' must be numeric or Datetime on both sides", opts.On)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	shared := map[string]bool{opts.On: true}
+	for _, columnName := range opts.By {
+		shared[columnName] = true
+	}
+	return assembleMerge(left, right, shared, rowRange(left.RowCount()), matches, opts.Suffixes)
+}
+
+// asOfKeys holds the By codes of both sides of an as-of join and the direction to match in
+type asOfKeys struct {
+	leftCodes  []int
+	rightCodes []int
+	size       int
+	direction  AsOfDirection
+}
+
+// asOfMatch returns for every left row the right row it pairs with, or -1. Right rows are split by
+// By code and swept in step with the left rows, so that every pointer only moves forward.
+func asOfMatch[K int64 | float64](keys asOfKeys, left, right *vector[K], tolerance K, bounded bool) ([]int, error) {
+	if !sortedIgnoringNulls(left) {
+		return nil, errors.New("left DataFrame is not sorted on the as-of join column")
+	}
+	if !sortedIgnoringNulls(right) {
+		return nil, errors.New("right DataFrame is not sorted on the as-of join column")
+	}
+
+	groups := make([][]int, keys.size)
+	for j, code := range keys.rightCodes {
+		if code >= 0 && right.valid.get(j) {
+			groups[code] = append(groups[code], j)
+		}
+	}
+	below := make([]int, keys.size)
+	atOrBelow := make([]int, keys.size)
+
+	matches := repeatIndex(-1, len(left.data))
+	for i, code := range keys.leftCodes {
+		if code < 0 || !left.valid.get(i) {
+			continue
+		}
+		key, group := left.data[i], groups[code]
+		for below[code] < len(group) && right.data[group[below[code]]] < key {
+			below[code]++
+		}
+		atOrBelow[code] = max(atOrBelow[code], below[code])
+		for atOrBelow[code] < len(group) && right.data[group[atOrBelow[code]]] <= key {
+			atOrBelow[code]++
+		}
+
+		backward, forward := -1, -1
+		if atOrBelow[code] > 0 {
+			backward = group[atOrBelow[code]-1]
+		}
+		if below[code] < len(group) {
+			forward = group[below[code]]
+		}
+
+		match := backward
+		switch keys.direction {
+		case Forward:
+			match = forward
+		case Nearest:
+			if backward < 0 || (forward >= 0 && right.data[forward]-key < key-right.data[backward]) {
+				match = forward
+			}
+		}
+
+		if match >= 0 && (!bounded || distance(key, right.data[match]) <= tolerance) {
+			matches[i] = match
+		}
+	}
+	return matches, nil
+}
+
+// sortedIgnoringNulls reports whether the non-missing values of a vector are in ascending order
+func sortedIgnoringNulls[K int64 | float64](v *vector[K]) bool {
+	previous := -1
+	for i := range v.data {
+		if !v.valid.get(i) {
+			continue
+		}
+		if previous >= 0 && cmp.Less(v.data[i], v.data[previous]) {
+			return false
+		}
+		previous = i
+	}
+	return true
+}
+
+// distance returns the absolute difference between two keys
+func distance[K int64 | float64](a, b K) K {
+	if a < b {
+		return b - a
+	}
+	return a - b
+}
+
+// unixNanos returns a Datetime Series as nanoseconds since the Unix epoch
+func unixNanos(s *Series) *vector[int64] {
+	times := s.data.(*vector[time.Time])
+	nanos := make([]int64, len(times.data))
+	for i, t := range times.data {
+		nanos[i] = t.UnixNano()
+	}
+	return makeVector(nanos, times.valid)
+}
+
+// rowRange returns the row indices 0 through n-1
+func rowRange(n int) []int {
+	rows := make([]int, n)
+	for i := range rows {
+		rows[i] = i
+	}
+	return rows
+}
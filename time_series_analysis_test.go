@@ -0,0 +1,13 @@
+package dataframe
+
+import "testing"
+
+func TestMergeAsOfRejectsUnknownDirection(t *testing.T) {
+	empty, err := NewDataFrameWithSchema(Schema{{Name: "time", Type: Int64}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := MergeAsOf(empty, empty, AsOfOptions{On: "time", Direction: Nearest + 1}); err == nil {
+		t.Error("expected an error for an unknown direction")
+	}
+}